@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// Mongo Model. Only the SHA-256 hash of the secret is stored.
+type APIKey struct {
+	ID        string     `bson:"_id"`
+	Name      string     `bson:"name"`
+	Site      string     `bson:"site"`
+	Methods   []string   `bson:"methods"` // short RPC names, e.g. "CheckIn"
+	Hash      string     `bson:"hash"`    // hex SHA-256 of the secret
+	CreatedAt time.Time  `bson:"created_at"`
+	RevokedAt *time.Time `bson:"revoked_at,omitempty"`
+}
+
+// APIKeyStore is the persistence layer behind kiosk API keys.
+type APIKeyStore interface {
+	// Create stores a new key.
+	Create(ctx context.Context, k *APIKey) error
+	// Get returns the key (revoked or not) or ErrNotFound.
+	Get(ctx context.Context, id string) (*APIKey, error)
+	// List returns keys ordered by id, skipping revoked ones unless asked.
+	List(ctx context.Context, includeRevoked bool) ([]APIKey, error)
+	// Revoke stamps RevokedAt (once) and returns the key, or ErrNotFound.
+	Revoke(ctx context.Context, id string, at time.Time) (*APIKey, error)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryAPIKeyStore keeps API keys in process memory.
+type memoryAPIKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]APIKey
+}
+
+func newMemoryAPIKeyStore() *memoryAPIKeyStore {
+	return &memoryAPIKeyStore{keys: map[string]APIKey{}}
+}
+
+func (m *memoryAPIKeyStore) Create(ctx context.Context, k *APIKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[k.ID] = *k
+	return nil
+}
+
+func (m *memoryAPIKeyStore) Get(ctx context.Context, id string) (*APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	k, ok := m.keys[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &k, nil
+}
+
+func (m *memoryAPIKeyStore) List(ctx context.Context, includeRevoked bool) ([]APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var keys []APIKey
+	for _, k := range m.keys {
+		if k.RevokedAt != nil && !includeRevoked {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys, nil
+}
+
+func (m *memoryAPIKeyStore) Revoke(ctx context.Context, id string, at time.Time) (*APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k, ok := m.keys[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if k.RevokedAt == nil {
+		k.RevokedAt = &at
+		m.keys[id] = k
+	}
+	return &k, nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoAPIKeyStore keeps API keys in a MongoDB collection keyed by key id.
+type mongoAPIKeyStore struct {
+	collection *mongo.Collection
+}
+
+func newMongoAPIKeyStore(collection *mongo.Collection) *mongoAPIKeyStore {
+	return &mongoAPIKeyStore{collection: collection}
+}
+
+func (m *mongoAPIKeyStore) Create(ctx context.Context, k *APIKey) error {
+	_, err := m.collection.InsertOne(ctx, k)
+	return err
+}
+
+func (m *mongoAPIKeyStore) Get(ctx context.Context, id string) (*APIKey, error) {
+	var k APIKey
+	err := m.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&k)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (m *mongoAPIKeyStore) List(ctx context.Context, includeRevoked bool) ([]APIKey, error) {
+	filter := bson.M{}
+	if !includeRevoked {
+		filter["revoked_at"] = bson.M{"$exists": false}
+	}
+	cursor, err := m.collection.Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (m *mongoAPIKeyStore) Revoke(ctx context.Context, id string, at time.Time) (*APIKey, error) {
+	// Keep the first revocation time if the key is revoked twice.
+	_, err := m.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": at}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m.Get(ctx, id)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// apiKeyHeader is the metadata key (and HTTP header) carrying a kiosk key.
+const apiKeyHeader = "x-api-key"
+
+// apiKeyPrefix starts every key: "ak_<id>.<secret>".
+const apiKeyPrefix = "ak_"
+
+// kioskMethods are the only RPCs an API key can ever be scoped to, by short
+// name.
+var kioskMethods = map[string]string{
+	"CheckIn":      pb.AttendanceService_CheckIn_FullMethodName,
+	"CheckOut":     pb.AttendanceService_CheckOut_FullMethodName,
+	"CheckOutUser": pb.AttendanceService_CheckOutUser_FullMethodName,
+}
+
+func hashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int, enc func([]byte) string) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand never fails on supported platforms
+	}
+	return enc(b)
+}
+
+// gRPC server struct for API key management
+type apiKeyServer struct {
+	pb.UnimplementedApiKeyServiceServer
+	keys   APIKeyStore
+	policy *accessPolicy
+}
+
+func toAPIKeyResponse(k *APIKey) *pb.ApiKey {
+	resp := &pb.ApiKey{
+		Id:        k.ID,
+		Name:      k.Name,
+		Site:      k.Site,
+		Methods:   k.Methods,
+		CreatedAt: timestamppb.New(k.CreatedAt),
+	}
+	if k.RevokedAt != nil {
+		resp.RevokedAt = timestamppb.New(*k.RevokedAt)
+	}
+	return resp
+}
+
+// --- gRPC Methods ---
+func (s *apiKeyServer) CreateApiKey(ctx context.Context, req *pb.CreateApiKeyRequest) (*pb.CreateApiKeyResponse, error) {
+	if err := s.policy.requireAdmin(ctx, "CreateApiKey"); err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" || req.GetSite() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name and site required")
+	}
+	methods := req.GetMethods()
+	if len(methods) == 0 {
+		methods = []string{"CheckIn", "CheckOut", "CheckOutUser"}
+	}
+	for _, m := range methods {
+		if _, ok := kioskMethods[m]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "method %q cannot be granted to an API key (want CheckIn, CheckOut or CheckOutUser)", m)
+		}
+	}
+
+	secret := randomString(32, base64.RawURLEncoding.EncodeToString)
+	k := APIKey{
+		ID:        randomString(8, hex.EncodeToString),
+		Name:      req.GetName(),
+		Site:      req.GetSite(),
+		Methods:   methods,
+		Hash:      hashAPIKeySecret(secret),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.keys.Create(ctx, &k); err != nil {
+		return nil, status.Errorf(codes.Internal, "insert error: %v", err)
+	}
+	return &pb.CreateApiKeyResponse{
+		ApiKey: toAPIKeyResponse(&k),
+		Key:    apiKeyPrefix + k.ID + "." + secret,
+	}, nil
+}
+
+func (s *apiKeyServer) ListApiKeys(ctx context.Context, req *pb.ListApiKeysRequest) (*pb.ListApiKeysResponse, error) {
+	if err := s.policy.requireAdmin(ctx, "ListApiKeys"); err != nil {
+		return nil, err
+	}
+	keys, err := s.keys.List(ctx, req.GetIncludeRevoked())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "find error: %v", err)
+	}
+	resp := &pb.ListApiKeysResponse{}
+	for i := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, toAPIKeyResponse(&keys[i]))
+	}
+	return resp, nil
+}
+
+func (s *apiKeyServer) RevokeApiKey(ctx context.Context, req *pb.RevokeApiKeyRequest) (*pb.ApiKey, error) {
+	if err := s.policy.requireAdmin(ctx, "RevokeApiKey"); err != nil {
+		return nil, err
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	k, err := s.keys.Revoke(ctx, req.GetId(), time.Now().UTC())
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "api key not found")
+		}
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	}
+	return toAPIKeyResponse(k), nil
+}
+
+// apiKeyAuthenticator validates kiosk API keys. A valid key authenticates
+// the caller as a kiosk for its site, and only for the methods it is scoped
+// to.
+type apiKeyAuthenticator struct {
+	keys APIKeyStore
+}
+
+func (a *apiKeyAuthenticator) authenticate(ctx context.Context, fullMethod, raw string) (context.Context, error) {
+	rest, ok := strings.CutPrefix(raw, apiKeyPrefix)
+	id, secret, _ := strings.Cut(rest, ".")
+	if !ok || id == "" || secret == "" {
+		return nil, status.Error(codes.Unauthenticated, "malformed API key")
+	}
+	k, err := a.keys.Get(ctx, id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+		return nil, status.Errorf(codes.Internal, "db error: %v", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(hashAPIKeySecret(secret)), []byte(k.Hash)) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	if k.RevokedAt != nil {
+		return nil, status.Error(codes.Unauthenticated, "API key revoked")
+	}
+
+	allowed := false
+	for _, m := range k.Methods {
+		if kioskMethods[m] == fullMethod {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "API key %q is not allowed to call %s", k.ID, fullMethod)
+	}
+	return withPrincipal(ctx, &Principal{Subject: "apikey:" + k.ID, Roles: []string{RoleKiosk}, Site: k.Site}), nil
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Principal is the authenticated caller of an RPC.
+type Principal struct {
+	Subject string
+	Roles   []string // from the "role" or "roles" claim
+	Claims  jwt.MapClaims
+	Site    string // kiosk site, for API key callers
+}
+
+// HasRole reports whether the caller holds the role.
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// rolesFrom reads roles from a "role" string or "roles" array claim. The
+// kiosk role is reserved for API keys and ignored here.
+func rolesFrom(claims jwt.MapClaims) []string {
+	var roles []string
+	if r, ok := claims["role"].(string); ok && r != "" && r != RoleKiosk {
+		roles = append(roles, r)
+	}
+	if rs, ok := claims["roles"].([]interface{}); ok {
+		for _, r := range rs {
+			if r, ok := r.(string); ok && r != "" && r != RoleKiosk {
+				roles = append(roles, r)
+			}
+		}
+	}
+	return roles
+}
+
+type principalKey struct{}
+
+func withPrincipal(ctx context.Context, p *Principal) context.Context {
+	if info, ok := requestInfoFrom(ctx); ok {
+		info.caller = p.Subject
+	}
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// principalFrom returns the caller put in the context by the auth
+// interceptor, if any.
+func principalFrom(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// authConfig selects how bearer tokens are verified. At least one of the key
+// sources must be set for authentication to be enabled.
+type authConfig struct {
+	HMACSecret string `yaml:"jwt_hs256_secret"`          // HS256 shared secret
+	RSAKeyFile string `yaml:"jwt_rs256_public_key_file"` // PEM-encoded RS256 public key
+	JWKSFile   string `yaml:"jwt_jwks_file"`             // local JWKS with RSA ("RSA") and/or HMAC ("oct") keys
+	Issuer     string `yaml:"jwt_issuer"`                // required "iss" claim, if set
+	Audience   string `yaml:"jwt_audience"`              // required "aud" claim, if set
+}
+
+func (c authConfig) enabled() bool {
+	return c.HMACSecret != "" || c.RSAKeyFile != "" || c.JWKSFile != ""
+}
+
+// jwtAuthenticator validates HS256/RS256 bearer tokens on incoming RPCs.
+type jwtAuthenticator struct {
+	hmacKey []byte
+	rsaKey  *rsa.PublicKey
+	kidKeys map[string]interface{} // JWKS keys by kid: []byte or *rsa.PublicKey
+	parser  *jwt.Parser
+}
+
+func newJWTAuthenticator(cfg authConfig) (*jwtAuthenticator, error) {
+	a := &jwtAuthenticator{kidKeys: map[string]interface{}{}}
+	if cfg.HMACSecret != "" {
+		a.hmacKey = []byte(cfg.HMACSecret)
+	}
+	if cfg.RSAKeyFile != "" {
+		pem, err := os.ReadFile(cfg.RSAKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read RS256 key: %w", err)
+		}
+		if a.rsaKey, err = jwt.ParseRSAPublicKeyFromPEM(pem); err != nil {
+			return nil, fmt.Errorf("parse RS256 key: %w", err)
+		}
+	}
+	if cfg.JWKSFile != "" {
+		if err := a.loadJWKS(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods([]string{"HS256", "RS256"}), jwt.WithExpirationRequired()}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	a.parser = jwt.NewParser(opts...)
+	return a, nil
+}
+
+// loadJWKS reads RSA and symmetric keys from a JSON Web Key Set file.
+func (a *jwtAuthenticator) loadJWKS(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read JWKS: %w", err)
+	}
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			K   string `json:"k"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return fmt.Errorf("parse JWKS: %w", err)
+	}
+	for _, k := range set.Keys {
+		if k.Kid == "" {
+			return fmt.Errorf("JWKS key without kid")
+		}
+		switch k.Kty {
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(k.N)
+			if err != nil {
+				return fmt.Errorf("JWKS key %q: bad n: %w", k.Kid, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(k.E)
+			if err != nil {
+				return fmt.Errorf("JWKS key %q: bad e: %w", k.Kid, err)
+			}
+			a.kidKeys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil {
+				return fmt.Errorf("JWKS key %q: bad k: %w", k.Kid, err)
+			}
+			a.kidKeys[k.Kid] = secret
+		default:
+			return fmt.Errorf("JWKS key %q: unsupported kty %q", k.Kid, k.Kty)
+		}
+	}
+	return nil
+}
+
+// keyFunc picks the verification key for a token: by kid from the JWKS when
+// the token names one, otherwise the configured key for its algorithm.
+func (a *jwtAuthenticator) keyFunc(t *jwt.Token) (interface{}, error) {
+	var key interface{}
+	if kid, _ := t.Header["kid"].(string); kid != "" {
+		key = a.kidKeys[kid]
+	} else if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok && a.hmacKey != nil {
+		key = a.hmacKey
+	} else if _, ok := t.Method.(*jwt.SigningMethodRSA); ok && a.rsaKey != nil {
+		key = a.rsaKey
+	}
+
+	switch k := key.(type) {
+	case []byte:
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+			return k, nil
+		}
+	case *rsa.PublicKey:
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); ok {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s key for token", t.Method.Alg())
+}
+
+// authenticate verifies the bearer token in the incoming metadata and
+// returns a context carrying the caller's Principal.
+func (a *jwtAuthenticator) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	raw, ok := strings.CutPrefix(vals[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(raw, claims, a.keyFunc); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	sub, _ := claims.GetSubject()
+	if sub == "" {
+		return nil, status.Error(codes.Unauthenticated, "token has no subject")
+	}
+	return withPrincipal(ctx, &Principal{Subject: sub, Roles: rolesFrom(claims), Claims: claims}), nil
+}
+
+// authInterceptor authenticates RPCs by kiosk API key (x-api-key) or, failing
+// that, by JWT bearer token. Without JWT keys configured, calls without an
+// API key pass through unauthenticated.
+type authInterceptor struct {
+	jwt    *jwtAuthenticator // nil when JWT authentication is disabled
+	keys   *apiKeyAuthenticator
+	public map[string]bool // full method names that skip authentication
+}
+
+func (a *authInterceptor) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(apiKeyHeader); len(vals) > 0 {
+		return a.keys.authenticate(ctx, fullMethod, vals[0])
+	}
+	if a.jwt == nil {
+		return ctx, nil
+	}
+	return a.jwt.authenticate(ctx)
+}
+
+func (a *authInterceptor) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if a.public[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *authInterceptor) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a.public[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream overrides the context of a grpc.ServerStream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
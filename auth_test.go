@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+const testHMACSecret = "test-secret"
+
+// writeRSAKey writes key's public half as PEM and returns the path and the
+// PEM bytes.
+func writeRSAKey(t *testing.T, key *rsa.PrivateKey) (string, []byte) {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	path := filepath.Join(t.TempDir(), "rs256.pem")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path, b
+}
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PrivateKey, octKid string, secret []byte) string {
+	t.Helper()
+	enc := base64.RawURLEncoding.EncodeToString
+	doc := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":%q,"n":%q,"e":%q},{"kty":"oct","kid":%q,"k":%q}]}`,
+		kid, enc(key.N.Bytes()), enc(big.NewInt(int64(key.E)).Bytes()), octKid, enc(secret))
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func bearerContext(tok string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+tok))
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemPath, pemBytes := writeRSAKey(t, rsaKey)
+	jwksSecret := []byte("jwks-secret")
+	jwksPath := writeJWKS(t, "rsa-1", rsaKey, "oct-1", jwksSecret)
+
+	a, err := newJWTAuthenticator(authConfig{
+		HMACSecret: testHMACSecret,
+		RSAKeyFile: pemPath,
+		JWKSFile:   jwksPath,
+		Issuer:     "issuer",
+		Audience:   "attendance",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+	claims := func(extra ...interface{}) jwt.MapClaims {
+		c := jwt.MapClaims{"sub": "u1", "exp": exp, "iss": "issuer", "aud": "attendance", "role": "admin"}
+		for i := 0; i+1 < len(extra); i += 2 {
+			k := extra[i].(string)
+			if extra[i+1] == nil {
+				delete(c, k)
+			} else {
+				c[k] = extra[i+1]
+			}
+		}
+		return c
+	}
+	hs, rs := jwt.SigningMethodHS256, jwt.SigningMethodRS256
+
+	for _, tc := range []struct {
+		name string
+		tok  string
+		ok   bool
+	}{
+		{"HS256 with secret", sign(t, hs, []byte(testHMACSecret), "", claims()), true},
+		{"RS256 with key file", sign(t, rs, rsaKey, "", claims()), true},
+		{"RS256 by JWKS kid", sign(t, rs, rsaKey, "rsa-1", claims()), true},
+		{"HS256 by JWKS kid", sign(t, hs, jwksSecret, "oct-1", claims()), true},
+		// Key confusion: an HS256 token keyed with the public RSA key must
+		// not verify against it.
+		{"HS256 signed with RSA public key", sign(t, hs, pemBytes, "", claims()), false},
+		{"HS256 naming RSA kid", sign(t, hs, pemBytes, "rsa-1", claims()), false},
+		{"RS256 naming oct kid", sign(t, rs, rsaKey, "oct-1", claims()), false},
+		{"unknown kid", sign(t, rs, rsaKey, "nope", claims()), false},
+		{"RS256 wrong key", sign(t, rs, otherKey, "", claims()), false},
+		{"HS256 wrong secret", sign(t, hs, []byte("guess"), "", claims()), false},
+		{"HS384 not allowed", sign(t, jwt.SigningMethodHS384, []byte(testHMACSecret), "", claims()), false},
+		{"alg none", sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", claims()), false},
+		{"expired", sign(t, hs, []byte(testHMACSecret), "", claims("exp", time.Now().Add(-time.Minute).Unix())), false},
+		{"no exp", sign(t, hs, []byte(testHMACSecret), "", claims("exp", nil)), false},
+		{"wrong issuer", sign(t, hs, []byte(testHMACSecret), "", claims("iss", "other")), false},
+		{"wrong audience", sign(t, hs, []byte(testHMACSecret), "", claims("aud", "other")), false},
+		{"no subject", sign(t, hs, []byte(testHMACSecret), "", claims("sub", nil)), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, err := a.authenticate(bearerContext(tc.tok))
+			if !tc.ok {
+				wantCode(t, err, codes.Unauthenticated)
+				return
+			}
+			if err != nil {
+				t.Fatalf("authenticate: %v", err)
+			}
+			p, ok := principalFrom(ctx)
+			if !ok || p.Subject != "u1" || !p.HasRole(RoleAdmin) {
+				t.Errorf("principal = %+v, want u1 with the admin role", p)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorHeader(t *testing.T) {
+	a, err := newJWTAuthenticator(authConfig{HMACSecret: testHMACSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = a.authenticate(context.Background())
+	wantCode(t, err, codes.Unauthenticated)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic dTpw"))
+	_, err = a.authenticate(ctx)
+	wantCode(t, err, codes.Unauthenticated)
+}
+
+func TestRolesFromIgnoresKiosk(t *testing.T) {
+	got := rolesFrom(jwt.MapClaims{"role": RoleKiosk, "roles": []interface{}{"manager", RoleKiosk, 7}})
+	if len(got) != 1 || got[0] != RoleManager {
+		t.Errorf("rolesFrom = %v, want [manager]", got)
+	}
+}
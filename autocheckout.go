@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Reasons recorded on auto-closed records.
+const (
+	closeReasonMaxSession = "max_session"
+	closeReasonShiftEnded = "shift_ended"
+	closeReasonDuplicate  = "duplicate_open_session" // see closeDuplicateOpenSessions
+)
+
+// autoCheckoutLease names the lease that elects the replica running sweeps.
+const autoCheckoutLease = "auto-checkout"
+
+// autoCheckout periodically checks out sessions that were left open: at
+// checkin+maxSession, or at the end of the matched shift once afterShift
+// has passed, whichever comes first. Only the replica holding the lease
+// sweeps; closing is conditional on the record still being open, so a
+// manual checkout or an overlapping sweep is never overwritten.
+type autoCheckout struct {
+	store      AttendanceStore
+	leases     LeaseStore
+	holder     string
+	interval   time.Duration
+	maxSession time.Duration
+	afterShift time.Duration
+}
+
+func newAutoCheckout(store AttendanceStore, leases LeaseStore, interval, maxSession, afterShift time.Duration) *autoCheckout {
+	host, _ := os.Hostname()
+	return &autoCheckout{
+		store:      store,
+		leases:     leases,
+		holder:     host + "-" + randomString(4, hex.EncodeToString),
+		interval:   interval,
+		maxSession: maxSession,
+		afterShift: afterShift,
+	}
+}
+
+// deadline returns when r is due to be closed, the checkout time to record
+// and why.
+func (a *autoCheckout) deadline(r *AttendanceRecord) (due, at time.Time, reason string) {
+	at = r.CheckinTime.Add(a.maxSession)
+	due, reason = at, closeReasonMaxSession
+	if o := r.Shift; o != nil && !o.Start.IsZero() {
+		if d := o.End.Add(a.afterShift); d.Before(due) {
+			due, at, reason = d, o.End, closeReasonShiftEnded
+		}
+	}
+	return due, at, reason
+}
+
+// run sweeps every interval until ctx is done, then releases the lease.
+func (a *autoCheckout) run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		a.tick(ctx)
+		select {
+		case <-ctx.Done():
+			rctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if err := a.leases.Release(rctx, autoCheckoutLease, a.holder); err != nil {
+				slog.Warn("Auto checkout: releasing lease failed", "error", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *autoCheckout) tick(ctx context.Context) {
+	now := time.Now().UTC()
+	// The ttl outlives a missed tick so the holder keeps the lease while
+	// healthy, and another replica takes over soon after it stops.
+	held, err := a.leases.Acquire(ctx, autoCheckoutLease, a.holder, now, 3*a.interval)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Warn("Auto checkout: acquiring lease failed", "error", err)
+		}
+		return
+	}
+	if !held {
+		slog.Debug("Auto checkout: lease held by another replica")
+		return
+	}
+	if err := a.sweep(ctx, now); err != nil && ctx.Err() == nil {
+		slog.Warn("Auto checkout: sweep failed", "error", err)
+	}
+}
+
+// sweep closes every open session that is due at now.
+func (a *autoCheckout) sweep(ctx context.Context, now time.Time) error {
+	type closing struct {
+		rec    AttendanceRecord
+		at     time.Time
+		reason string
+	}
+	var due []closing
+	err := a.store.Each(ctx, RecordQuery{RecordFilter: RecordFilter{State: OpenSession}, Ascending: true}, func(r *AttendanceRecord) error {
+		if d, at, reason := a.deadline(r); !now.Before(d) {
+			due = append(due, closing{*r, at, reason})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range due {
+		rec, err := a.store.AutoCloseSession(ctx, c.rec.ID, c.at, c.reason)
+		if err == ErrSessionClosed {
+			continue // checked out meanwhile
+		}
+		if err != nil {
+			return err
+		}
+		autoCheckOuts.WithLabelValues(c.reason).Inc()
+		slog.Info("Auto checkout", "record_id", rec.ID.Hex(), "user_id", rec.UserID,
+			"reason", c.reason, "checkout_at", c.at, "worked", rec.Worked(now).String())
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestAutoCheckout(store AttendanceStore, leases LeaseStore) *autoCheckout {
+	return &autoCheckout{
+		store:      store,
+		leases:     leases,
+		holder:     "test",
+		interval:   time.Minute,
+		maxSession: 16 * time.Hour,
+		afterShift: 2 * time.Hour,
+	}
+}
+
+func TestAutoCheckoutDeadline(t *testing.T) {
+	a := newTestAutoCheckout(nil, nil)
+	shift := func(start, end time.Duration) *ShiftOccurrence {
+		return &ShiftOccurrence{ShiftID: "s", Start: t0.Add(start), End: t0.Add(end)}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		in      time.Duration // check-in, after t0
+		shift   *ShiftOccurrence
+		due, at time.Duration
+		reason  string
+	}{
+		{"no shift", 0, nil, 16 * time.Hour, 16 * time.Hour, closeReasonMaxSession},
+		{"off shift", 0, &ShiftOccurrence{ShiftID: "s"}, 16 * time.Hour, 16 * time.Hour, closeReasonMaxSession},
+		{"shift ends first", 0, shift(0, 8*time.Hour), 10 * time.Hour, 8 * time.Hour, closeReasonShiftEnded},
+		{"max session first", -time.Hour, shift(0, 14*time.Hour), 15 * time.Hour, 15 * time.Hour, closeReasonMaxSession},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			due, at, reason := a.deadline(&AttendanceRecord{CheckinTime: t0.Add(tc.in), Shift: tc.shift})
+			if !due.Equal(t0.Add(tc.due)) || !at.Equal(t0.Add(tc.at)) || reason != tc.reason {
+				t.Errorf("deadline = %v, %v, %s; want %v, %v, %s", due, at, reason, t0.Add(tc.due), t0.Add(tc.at), tc.reason)
+			}
+		})
+	}
+}
+
+func TestAutoCheckoutSweep(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryStore()
+	add := func(userID string, in time.Duration, shift *ShiftOccurrence) primitive.ObjectID {
+		t.Helper()
+		rec := &AttendanceRecord{ID: primitive.NewObjectID(), UserID: userID, CheckinTime: t0.Add(in), Shift: shift}
+		if err := m.Insert(ctx, rec); err != nil {
+			t.Fatal(err)
+		}
+		return rec.ID
+	}
+	stale := add("stale", 0, nil)
+	fresh := add("fresh", 2*time.Hour, nil)
+	shifted := add("shifted", 0, &ShiftOccurrence{ShiftID: "s", Start: t0, End: t0.Add(8 * time.Hour)})
+	closed := add("closed", 0, nil)
+	if _, err := m.CloseSession(ctx, closed, t0.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	a := newTestAutoCheckout(m, newMemoryLeaseStore())
+	if err := a.sweep(ctx, t0.Add(17*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		id     primitive.ObjectID
+		out    time.Duration // -1 while still open
+		reason string
+	}{
+		{"open past max session", stale, 16 * time.Hour, closeReasonMaxSession},
+		{"not yet due", fresh, -1, ""},
+		{"past shift end", shifted, 8 * time.Hour, closeReasonShiftEnded},
+		{"checked out by hand", closed, time.Hour, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := m.Get(ctx, tc.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.out < 0 {
+				if r.CheckoutTime != nil {
+					t.Errorf("closed at %v, want still open", r.CheckoutTime)
+				}
+				return
+			}
+			if r.CheckoutTime == nil || !r.CheckoutTime.Equal(t0.Add(tc.out)) {
+				t.Errorf("checkout = %v, want %v", r.CheckoutTime, t0.Add(tc.out))
+			}
+			if r.AutoClosed != (tc.reason != "") || r.CloseReason != tc.reason {
+				t.Errorf("auto_closed %v, reason %q; want reason %q", r.AutoClosed, r.CloseReason, tc.reason)
+			}
+		})
+	}
+}
+
+func TestAutoCheckoutTickNeedsLease(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryStore()
+	rec := insertRecord(t, m, "u1", t0, time.Time{}) // long overdue
+	leases := newMemoryLeaseStore()
+	if ok, _ := leases.Acquire(ctx, autoCheckoutLease, "other", time.Now(), time.Hour); !ok {
+		t.Fatal("could not take the lease for another replica")
+	}
+	a := newTestAutoCheckout(m, leases)
+
+	a.tick(ctx)
+	if r, _ := m.Get(ctx, rec.ID); r.CheckoutTime != nil {
+		t.Fatal("swept without holding the lease")
+	}
+	if err := leases.Release(ctx, autoCheckoutLease, "other"); err != nil {
+		t.Fatal(err)
+	}
+	a.tick(ctx)
+	if r, _ := m.Get(ctx, rec.ID); r.CheckoutTime == nil || r.CloseReason != closeReasonMaxSession {
+		t.Errorf("record = %+v, want it auto-closed once the lease was free", r)
+	}
+}
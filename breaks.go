@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Errors returned by AttendanceStore.StartBreak and EndBreak.
+var (
+	ErrOnBreak      = errors.New("user is already on a break")
+	ErrNotOnBreak   = errors.New("user is not on a break")
+	ErrInvalidBreak = errors.New("break overlaps another break or falls outside the session")
+)
+
+// Break is an interval within a session that does not count as worked.
+type Break struct {
+	Start time.Time  `bson:"start"`
+	End   *time.Time `bson:"end,omitempty"` // nil while on the break
+}
+
+// openBreak returns the break still running, if any.
+func (r *AttendanceRecord) openBreak() *Break {
+	for i := range r.Breaks {
+		if r.Breaks[i].End == nil {
+			return &r.Breaks[i]
+		}
+	}
+	return nil
+}
+
+// breakTime returns how much of [from, to) was spent on breaks; a break
+// still running counts up to to.
+func (r *AttendanceRecord) breakTime(from, to time.Time) time.Duration {
+	var d time.Duration
+	for _, b := range r.Breaks {
+		start, end := b.Start, to
+		if b.End != nil && b.End.Before(to) {
+			end = *b.End
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(start) {
+			d += end.Sub(start)
+		}
+	}
+	return d
+}
+
+// checkBreakStart reports why a break cannot start at at in the open
+// record r.
+func checkBreakStart(r *AttendanceRecord, at time.Time) error {
+	if r.openBreak() != nil {
+		return ErrOnBreak
+	}
+	if at.Before(r.CheckinTime) {
+		return ErrInvalidBreak
+	}
+	for _, b := range r.Breaks {
+		if at.Before(*b.End) {
+			return ErrInvalidBreak
+		}
+	}
+	return nil
+}
+
+// checkBreakEnd reports why the running break cannot end at at.
+func checkBreakEnd(r *AttendanceRecord, at time.Time) error {
+	b := r.openBreak()
+	if b == nil {
+		return ErrNotOnBreak
+	}
+	if at.Before(b.Start) {
+		return ErrInvalidBreak
+	}
+	return nil
+}
+
+// closeBreaks ends a running break at checkout and trims breaks to the
+// session, so they never extend past at. It builds a new slice, leaving any
+// copies of r untouched.
+func (r *AttendanceRecord) closeBreaks(at time.Time) {
+	var kept []Break
+	for _, b := range r.Breaks {
+		if !b.Start.Before(at) {
+			continue
+		}
+		if b.End == nil || b.End.After(at) {
+			t := at
+			b.End = &t
+		}
+		kept = append(kept, b)
+	}
+	r.Breaks = kept
+}
+
+func toBreakResponses(r *AttendanceRecord, now time.Time) []*pb.Break {
+	var out []*pb.Break
+	for _, b := range r.Breaks {
+		resp := &pb.Break{StartAt: timestamppb.New(b.Start)}
+		end := now
+		if b.End != nil {
+			end = *b.End
+			resp.EndAt = timestamppb.New(end)
+		}
+		resp.Duration = durationpb.New(end.Sub(b.Start).Truncate(time.Second))
+		out = append(out, resp)
+	}
+	return out
+}
+
+func breakError(err error) error {
+	switch err {
+	case ErrNotFound:
+		return status.Error(codes.NotFound, "no open session for user")
+	case ErrOnBreak, ErrNotOnBreak, ErrInvalidBreak:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, "update error: %v", err)
+}
+
+// --- gRPC Methods ---
+func (s *attendanceServer) StartBreak(ctx context.Context, req *pb.StartBreakRequest) (*pb.AttendanceRecordResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	if err := s.policy.canActAs(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	loc, err := s.locationFor(ctx, req.GetTimeZone(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.store.OpenByUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, breakError(err)
+	}
+	if err := s.policy.canActAtSite(ctx, open); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.StartBreak(ctx, req.GetUserId(), time.Now().UTC())
+	if err != nil {
+		return nil, breakError(err)
+	}
+	return toResponse(updated, loc, "Break started"), nil
+}
+
+func (s *attendanceServer) EndBreak(ctx context.Context, req *pb.EndBreakRequest) (*pb.AttendanceRecordResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	if err := s.policy.canActAs(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	loc, err := s.locationFor(ctx, req.GetTimeZone(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.store.OpenByUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, breakError(err)
+	}
+	if err := s.policy.canActAtSite(ctx, open); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.EndBreak(ctx, req.GetUserId(), time.Now().UTC())
+	if err != nil {
+		return nil, breakError(err)
+	}
+	return toResponse(updated, loc, "Break ended"), nil
+}
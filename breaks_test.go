@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+// session returns an open record checked in at t0 with breaks given as
+// offsets from t0; a negative end leaves the break running.
+func session(breaks ...[2]time.Duration) *AttendanceRecord {
+	r := &AttendanceRecord{UserID: "u1", CheckinTime: t0}
+	for _, b := range breaks {
+		br := Break{Start: t0.Add(b[0])}
+		if b[1] >= 0 {
+			end := t0.Add(b[1])
+			br.End = &end
+		}
+		r.Breaks = append(r.Breaks, br)
+	}
+	return r
+}
+
+func TestCheckBreakStartAndEnd(t *testing.T) {
+	h := time.Hour
+	done := [2]time.Duration{h, 2 * h}
+	running := [2]time.Duration{3 * h, -1}
+
+	for _, tc := range []struct {
+		name string
+		r    *AttendanceRecord
+		at   time.Duration
+		err  error
+		end  bool // checkBreakEnd instead of checkBreakStart
+	}{
+		{"start", session(), h, nil, false},
+		{"start before check-in", session(), -time.Minute, ErrInvalidBreak, false},
+		{"start while on a break", session(running), 4 * h, ErrOnBreak, false},
+		{"start inside a past break", session(done), 90 * time.Minute, ErrInvalidBreak, false},
+		{"start as a break ends", session(done), 2 * h, nil, false},
+		{"end", session(running), 4 * h, nil, true},
+		{"end at its start", session(running), 3 * h, nil, true},
+		{"end before its start", session(running), 2 * h, ErrInvalidBreak, true},
+		{"end without a break", session(done), 4 * h, ErrNotOnBreak, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			check := checkBreakStart
+			if tc.end {
+				check = checkBreakEnd
+			}
+			if err := check(tc.r, t0.Add(tc.at)); err != tc.err {
+				t.Errorf("err = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCloseBreaks(t *testing.T) {
+	h := time.Hour
+	r := session([2]time.Duration{h, 2 * h}, [2]time.Duration{3 * h, 5 * h}, [2]time.Duration{6 * h, -1})
+	copied := *r
+
+	r.closeBreaks(t0.Add(4 * h))
+	if len(r.Breaks) != 2 {
+		t.Fatalf("breaks = %+v, want the break after checkout dropped", r.Breaks)
+	}
+	if !r.Breaks[0].End.Equal(t0.Add(2 * h)) {
+		t.Errorf("finished break end = %v, want it unchanged", r.Breaks[0].End)
+	}
+	if !r.Breaks[1].End.Equal(t0.Add(4 * h)) {
+		t.Errorf("overlapping break end = %v, want it trimmed to checkout", r.Breaks[1].End)
+	}
+	if len(copied.Breaks) != 3 || copied.Breaks[2].End != nil || !copied.Breaks[1].End.Equal(t0.Add(5*h)) {
+		t.Errorf("copy changed to %+v", copied.Breaks)
+	}
+
+	r = session([2]time.Duration{h, -1})
+	r.closeBreaks(t0.Add(2 * h))
+	if r.openBreak() != nil || !r.Breaks[0].End.Equal(t0.Add(2*h)) {
+		t.Errorf("running break = %+v, want it ended at checkout", r.Breaks[0])
+	}
+}
+
+func TestBreakTimeAndWorked(t *testing.T) {
+	h := time.Hour
+	r := session([2]time.Duration{h, 2 * h}, [2]time.Duration{3 * h, -1})
+
+	for _, tc := range []struct {
+		name     string
+		from, to time.Duration
+		want     time.Duration
+	}{
+		{"whole session", 0, 4 * h, 2 * h},
+		{"clipped at from", 90 * time.Minute, 4 * h, 90 * time.Minute},
+		{"running break up to to", 0, 3*h + 30*time.Minute, 90 * time.Minute},
+		{"before any break", 0, h, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := r.breakTime(t0.Add(tc.from), t0.Add(tc.to)); got != tc.want {
+				t.Errorf("breakTime = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if got := r.Worked(t0.Add(4 * h)); got != 2*h {
+		t.Errorf("open Worked = %v, want 2h", got)
+	}
+	out := t0.Add(8 * h)
+	r.CheckoutTime = &out
+	r.closeBreaks(out)
+	if got := r.Worked(t0.Add(24 * h)); got != 2*h { // 8h less 1h and 5h of breaks
+		t.Errorf("closed Worked = %v, want 2h", got)
+	}
+}
+
+func TestStartEndBreakRPCs(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "u1")
+
+	_, err := s.StartBreak(ctx, &pb.StartBreakRequest{UserId: "u1"})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.StartBreak(ctx, &pb.StartBreakRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+
+	in, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.EndBreak(ctx, &pb.EndBreakRequest{UserId: "u1"})
+	wantCode(t, err, codes.FailedPrecondition)
+
+	started, err := s.StartBreak(ctx, &pb.StartBreakRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatalf("StartBreak: %v", err)
+	}
+	if b := started.GetBreaks(); len(b) != 1 || b[0].GetEndAt() != nil {
+		t.Errorf("breaks = %v, want one running break", b)
+	}
+	_, err = s.StartBreak(ctx, &pb.StartBreakRequest{UserId: "u1"})
+	wantCode(t, err, codes.FailedPrecondition)
+
+	ended, err := s.EndBreak(ctx, &pb.EndBreakRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatalf("EndBreak: %v", err)
+	}
+	if b := ended.GetBreaks(); len(b) != 1 || b[0].GetEndAt() == nil {
+		t.Errorf("breaks = %v, want one ended break", b)
+	}
+
+	// Checking out ends a running break.
+	if _, err := s.StartBreak(ctx, &pb.StartBreakRequest{UserId: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: in.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := out.GetBreaks()
+	if len(b) != 2 || b[1].GetEndAt() == nil || !b[1].GetEndAt().AsTime().Equal(out.GetCheckoutAt().AsTime()) {
+		t.Errorf("breaks = %v, want the running break ended at checkout", b)
+	}
+}
+
+func TestAPIKeysCannotTakeBreaks(t *testing.T) {
+	ctx := context.Background()
+	keys := newMemoryAPIKeyStore()
+	s := &apiKeyServer{keys: keys, policy: &accessPolicy{}}
+
+	for _, m := range []string{"StartBreak", "EndBreak"} {
+		_, err := s.CreateApiKey(ctx, &pb.CreateApiKeyRequest{Name: "door", Site: "A", Methods: []string{m}})
+		wantCode(t, err, codes.InvalidArgument)
+	}
+
+	created, err := s.CreateApiKey(ctx, &pb.CreateApiKeyRequest{Name: "door", Site: "A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &apiKeyAuthenticator{keys: keys}
+	if _, err := a.authenticate(ctx, pb.AttendanceService_CheckIn_FullMethodName, created.GetKey()); err != nil {
+		t.Errorf("CheckIn with a default key: %v", err)
+	}
+	for _, method := range []string{pb.AttendanceService_StartBreak_FullMethodName, pb.AttendanceService_EndBreak_FullMethodName} {
+		_, err := a.authenticate(ctx, method, created.GetKey())
+		wantCode(t, err, codes.PermissionDenied)
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the service configuration. Values come from, in increasing
+// precedence: the defaults below, the YAML file given by -config or
+// CONFIG_FILE, environment variables, and command-line flags.
+type Config struct {
+	GRPCPort        string        `yaml:"grpc_port"`
+	HTTPPort        string        `yaml:"http_port"`
+	LogLevel        string        `yaml:"log_level"`
+	TimeZone        string        `yaml:"time_zone"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	DrainDelay      time.Duration `yaml:"shutdown_drain_delay"` // serve on after readiness fails; part of ShutdownTimeout
+
+	Store struct {
+		Backend           string        `yaml:"backend"` // mongo or memory
+		MongoURI          string        `yaml:"mongo_uri"`
+		Database          string        `yaml:"database"`
+		RecordsCollection string        `yaml:"records_collection"`
+		UsersCollection   string        `yaml:"users_collection"`
+		APIKeysCollection string        `yaml:"api_keys_collection"`
+		ShiftsCollection  string        `yaml:"shifts_collection"`
+		LeasesCollection  string        `yaml:"leases_collection"`
+		ConnectTimeout    time.Duration `yaml:"connect_timeout"`
+		PingInterval      time.Duration `yaml:"ping_interval"` // readiness checks
+	} `yaml:"store"`
+
+	Auth authConfig `yaml:"auth"`
+
+	TLS struct {
+		CertFile       string        `yaml:"cert_file"`
+		KeyFile        string        `yaml:"key_file"`
+		CAFile         string        `yaml:"ca_file"`
+		ClientAuth     string        `yaml:"client_auth"`
+		ServerName     string        `yaml:"server_name"` // verified by the gateway
+		ReloadInterval time.Duration `yaml:"reload_interval"`
+	} `yaml:"tls"`
+
+	Tracing struct {
+		Exporter string `yaml:"exporter"` // otlp, stdout or none
+	} `yaml:"tracing"`
+
+	AutoCheckout struct {
+		Enabled       bool          `yaml:"enabled"`
+		Interval      time.Duration `yaml:"interval"`
+		MaxSession    time.Duration `yaml:"max_session"`
+		AfterShiftEnd time.Duration `yaml:"after_shift_end"` // wait before closing at shift end
+	} `yaml:"auto_checkout"`
+}
+
+func defaultConfig() *Config {
+	c := &Config{
+		GRPCPort:        "50052",
+		HTTPPort:        "8080",
+		LogLevel:        "info",
+		TimeZone:        "Asia/Kolkata",
+		ShutdownTimeout: 25 * time.Second,
+		DrainDelay:      5 * time.Second,
+	}
+	c.Store.Backend = "mongo"
+	c.Store.MongoURI = "mongodb://localhost:27017"
+	c.Store.Database = "attendance_db"
+	c.Store.RecordsCollection = "records"
+	c.Store.UsersCollection = "users"
+	c.Store.APIKeysCollection = "api_keys"
+	c.Store.ShiftsCollection = "shifts"
+	c.Store.LeasesCollection = "leases"
+	c.Store.ConnectTimeout = 10 * time.Second
+	c.Store.PingInterval = 5 * time.Second
+	c.TLS.ServerName = "localhost"
+	c.TLS.ReloadInterval = 10 * time.Second
+	c.Tracing.Exporter = "none"
+	c.AutoCheckout.Interval = time.Minute
+	c.AutoCheckout.MaxSession = 16 * time.Hour
+	c.AutoCheckout.AfterShiftEnd = 2 * time.Hour
+	return c
+}
+
+// setting ties a Config field to its environment variable and flag.
+type setting struct {
+	env, flag string
+	target    interface{}         // *string, *bool or *time.Duration
+	mask      func(string) string // hides credentials when logged, if set
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		{"GRPC_PORT", "grpc-port", &c.GRPCPort, nil},
+		{"HTTP_PORT", "http-port", &c.HTTPPort, nil},
+		{"LOG_LEVEL", "log-level", &c.LogLevel, nil},
+		{"TIMEZONE", "time-zone", &c.TimeZone, nil},
+		{"SHUTDOWN_TIMEOUT", "shutdown-timeout", &c.ShutdownTimeout, nil},
+		{"SHUTDOWN_DRAIN_DELAY", "shutdown-drain-delay", &c.DrainDelay, nil},
+		{"STORE_BACKEND", "store-backend", &c.Store.Backend, nil},
+		{"MONGO_URI", "mongo-uri", &c.Store.MongoURI, redactMongoURI},
+		{"MONGO_DATABASE", "mongo-database", &c.Store.Database, nil},
+		{"MONGO_RECORDS_COLLECTION", "mongo-records-collection", &c.Store.RecordsCollection, nil},
+		{"MONGO_USERS_COLLECTION", "mongo-users-collection", &c.Store.UsersCollection, nil},
+		{"MONGO_API_KEYS_COLLECTION", "mongo-api-keys-collection", &c.Store.APIKeysCollection, nil},
+		{"MONGO_SHIFTS_COLLECTION", "mongo-shifts-collection", &c.Store.ShiftsCollection, nil},
+		{"MONGO_LEASES_COLLECTION", "mongo-leases-collection", &c.Store.LeasesCollection, nil},
+		{"MONGO_CONNECT_TIMEOUT", "mongo-connect-timeout", &c.Store.ConnectTimeout, nil},
+		{"MONGO_PING_INTERVAL", "mongo-ping-interval", &c.Store.PingInterval, nil},
+		{"JWT_HS256_SECRET", "jwt-hs256-secret", &c.Auth.HMACSecret, maskSecret},
+		{"JWT_RS256_PUBLIC_KEY_FILE", "jwt-rs256-public-key-file", &c.Auth.RSAKeyFile, nil},
+		{"JWT_JWKS_FILE", "jwt-jwks-file", &c.Auth.JWKSFile, nil},
+		{"JWT_ISSUER", "jwt-issuer", &c.Auth.Issuer, nil},
+		{"JWT_AUDIENCE", "jwt-audience", &c.Auth.Audience, nil},
+		{"TLS_CERT_FILE", "tls-cert-file", &c.TLS.CertFile, nil},
+		{"TLS_KEY_FILE", "tls-key-file", &c.TLS.KeyFile, nil},
+		{"TLS_CA_FILE", "tls-ca-file", &c.TLS.CAFile, nil},
+		{"TLS_CLIENT_AUTH", "tls-client-auth", &c.TLS.ClientAuth, nil},
+		{"TLS_SERVER_NAME", "tls-server-name", &c.TLS.ServerName, nil},
+		{"TLS_RELOAD_INTERVAL", "tls-reload-interval", &c.TLS.ReloadInterval, nil},
+		{"OTEL_TRACES_EXPORTER", "tracing-exporter", &c.Tracing.Exporter, nil},
+		{"AUTO_CHECKOUT_ENABLED", "auto-checkout-enabled", &c.AutoCheckout.Enabled, nil},
+		{"AUTO_CHECKOUT_INTERVAL", "auto-checkout-interval", &c.AutoCheckout.Interval, nil},
+		{"AUTO_CHECKOUT_MAX_SESSION", "auto-checkout-max-session", &c.AutoCheckout.MaxSession, nil},
+		{"AUTO_CHECKOUT_AFTER_SHIFT_END", "auto-checkout-after-shift-end", &c.AutoCheckout.AfterShiftEnd, nil},
+	}
+}
+
+func (s setting) set(v string) error {
+	switch t := s.target.(type) {
+	case *string:
+		*t = v
+	case *bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		*t = b
+	case *time.Duration:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*t = d
+	}
+	return nil
+}
+
+func (s setting) String() string {
+	switch t := s.target.(type) {
+	case *string:
+		if s.mask != nil && *t != "" {
+			return s.mask(*t)
+		}
+		return *t
+	case *bool:
+		return strconv.FormatBool(*t)
+	case *time.Duration:
+		return t.String()
+	}
+	return ""
+}
+
+func maskSecret(string) string { return "****" }
+
+// uriSecretOptions matches connection string options that carry credentials.
+var uriSecretOptions = regexp.MustCompile(`(?i)([?&;](?:authMechanismProperties|tlsCertificateKeyFilePassword|sslClientCertificateKeyPassword)=)[^&;]*`)
+
+// redactMongoURI masks the password and credential options of a MongoDB
+// connection string, or all of it if the driver cannot parse it.
+func redactMongoURI(uri string) string {
+	if _, err := connstring.Parse(uri); err != nil {
+		return "****"
+	}
+	scheme, rest, _ := strings.Cut(uri, "://")
+	// Like the driver, take the user info to end at the first @.
+	if user, hosts, ok := strings.Cut(rest, "@"); ok {
+		if name, _, ok := strings.Cut(user, ":"); ok {
+			rest = name + ":****@" + hosts
+		}
+	}
+	return scheme + "://" + uriSecretOptions.ReplaceAllString(rest, "${1}****")
+}
+
+// loadConfig builds the configuration from defaults, the YAML file, the
+// environment and args (without the program name).
+func loadConfig(args []string) (*Config, error) {
+	cfg := defaultConfig()
+	settings := cfg.settings()
+
+	// Flags are parsed first to find -config, but applied last.
+	fs := flag.NewFlagSet("attendance", flag.ContinueOnError)
+	path := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML configuration file")
+	type flagValue struct {
+		s setting
+		v string
+	}
+	var flagged []flagValue
+	for _, s := range settings {
+		s := s
+		fs.Func(s.flag, "overrides "+s.env, func(v string) error {
+			flagged = append(flagged, flagValue{s, v})
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *path != "" {
+		b, err := os.ReadFile(*path)
+		if err != nil {
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("parse config %s: %w", *path, err)
+		}
+	}
+	for _, s := range settings {
+		if v, ok := os.LookupEnv(s.env); ok && v != "" {
+			if err := s.set(v); err != nil {
+				return nil, fmt.Errorf("%s: %w", s.env, err)
+			}
+		}
+	}
+	for _, f := range flagged {
+		if err := f.s.set(f.v); err != nil {
+			return nil, fmt.Errorf("-%s: %w", f.s.flag, err)
+		}
+	}
+	return cfg, cfg.validate()
+}
+
+// validate reports every invalid setting at once.
+func (c *Config) validate() error {
+	var errs []error
+	bad := func(format string, args ...interface{}) { errs = append(errs, fmt.Errorf(format, args...)) }
+
+	for _, p := range []struct{ name, port string }{{"grpc_port", c.GRPCPort}, {"http_port", c.HTTPPort}} {
+		if n, err := strconv.Atoi(p.port); err != nil || n < 1 || n > 65535 {
+			bad("%s: %q is not a port number", p.name, p.port)
+		}
+	}
+	if c.GRPCPort == c.HTTPPort {
+		bad("grpc_port and http_port must differ")
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		bad("log_level: %q is not debug, info, warn or error", c.LogLevel)
+	}
+	if _, err := loadZone(c.TimeZone); err != nil {
+		bad("time_zone: unknown zone %q, want an IANA name such as Asia/Kolkata", c.TimeZone)
+	}
+	switch c.Store.Backend {
+	case "memory":
+	case "mongo":
+		// As the driver parses it: IPv6 and multiple hosts are fine, and
+		// mongodb+srv:// names are looked up in DNS.
+		if _, err := connstring.ParseAndValidate(c.Store.MongoURI); err != nil {
+			bad("store.mongo_uri: %v", err)
+		}
+		if c.Store.Database == "" || c.Store.RecordsCollection == "" || c.Store.UsersCollection == "" ||
+			c.Store.APIKeysCollection == "" || c.Store.ShiftsCollection == "" || c.Store.LeasesCollection == "" {
+			bad("store: database and collection names must not be empty")
+		}
+	default:
+		bad("store.backend: %q is not mongo or memory", c.Store.Backend)
+	}
+	for _, d := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"shutdown_timeout", c.ShutdownTimeout},
+		{"store.connect_timeout", c.Store.ConnectTimeout},
+		{"store.ping_interval", c.Store.PingInterval},
+		{"tls.reload_interval", c.TLS.ReloadInterval},
+		{"auto_checkout.interval", c.AutoCheckout.Interval},
+		{"auto_checkout.max_session", c.AutoCheckout.MaxSession},
+	} {
+		if d.d <= 0 {
+			bad("%s: must be positive, got %s", d.name, d.d)
+		}
+	}
+	if c.DrainDelay < 0 || c.DrainDelay >= c.ShutdownTimeout {
+		bad("shutdown_drain_delay: must be at least 0 and less than shutdown_timeout (%s), got %s", c.ShutdownTimeout, c.DrainDelay)
+	}
+	if c.AutoCheckout.AfterShiftEnd < 0 {
+		bad("auto_checkout.after_shift_end: must not be negative, got %s", c.AutoCheckout.AfterShiftEnd)
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		bad("tls: cert_file and key_file must be set together")
+	}
+	if c.TLS.CAFile != "" && c.TLS.CertFile == "" {
+		bad("tls: ca_file requires cert_file and key_file")
+	}
+	if _, err := parseClientAuth(c.TLS.ClientAuth); err != nil {
+		bad("tls.client_auth: %q is not require, request or none", c.TLS.ClientAuth)
+	}
+	switch c.Tracing.Exporter {
+	case "", "none", "otlp", "stdout":
+	default:
+		bad("tracing.exporter: %q is not otlp, stdout or none", c.Tracing.Exporter)
+	}
+	return errors.Join(errs...)
+}
+
+// logConfig prints the effective configuration with secrets masked.
+func (c *Config) logConfig() {
+	var attrs []any
+	for _, s := range c.settings() {
+		attrs = append(attrs, slog.String(s.env, s.String()))
+	}
+	slog.Info("Configuration", attrs...)
+}
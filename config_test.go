@@ -0,0 +1,161 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	path := writeConfig(t, `
+grpc_port: "6000"
+http_port: "6001"
+log_level: debug
+store:
+  backend: memory
+auto_checkout:
+  max_session: 10h
+`)
+
+	for _, tc := range []struct {
+		name    string
+		env     map[string]string
+		args    []string
+		grpc    string
+		http    string
+		level   string
+		session time.Duration
+	}{
+		{"defaults", nil, nil, "50052", "8080", "info", 16 * time.Hour},
+		{"yaml over defaults", nil, []string{"-config", path}, "6000", "6001", "debug", 10 * time.Hour},
+		{"CONFIG_FILE", map[string]string{"CONFIG_FILE": path}, nil, "6000", "6001", "debug", 10 * time.Hour},
+		{"env over yaml", map[string]string{"CONFIG_FILE": path, "GRPC_PORT": "7000", "AUTO_CHECKOUT_MAX_SESSION": "12h"}, nil,
+			"7000", "6001", "debug", 12 * time.Hour},
+		{"flag over env", map[string]string{"GRPC_PORT": "7000"}, []string{"-config", path, "-grpc-port", "8000", "-log-level", "warn"},
+			"8000", "6001", "warn", 10 * time.Hour},
+		{"empty env is ignored", map[string]string{"CONFIG_FILE": path, "GRPC_PORT": ""}, nil, "6000", "6001", "debug", 10 * time.Hour},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			cfg, err := loadConfig(tc.args)
+			if err != nil {
+				t.Fatalf("loadConfig: %v", err)
+			}
+			if cfg.GRPCPort != tc.grpc || cfg.HTTPPort != tc.http || cfg.LogLevel != tc.level || cfg.AutoCheckout.MaxSession != tc.session {
+				t.Errorf("got grpc %s, http %s, log %s, max_session %s; want %s, %s, %s, %s",
+					cfg.GRPCPort, cfg.HTTPPort, cfg.LogLevel, cfg.AutoCheckout.MaxSession, tc.grpc, tc.http, tc.level, tc.session)
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		yaml string
+		env  map[string]string
+		args []string
+		want []string // substrings of the error
+	}{
+		{"unknown yaml key", "grcp_port: 1\n", nil, nil, []string{"field grcp_port not found"}},
+		{"bad duration env", "", map[string]string{"SHUTDOWN_TIMEOUT": "soon"}, nil, []string{"SHUTDOWN_TIMEOUT"}},
+		{"bad bool flag", "", nil, []string{"-auto-checkout-enabled", "maybe"}, []string{"-auto-checkout-enabled"}},
+		{"local zone", "time_zone: Local\n", nil, nil, []string{`time_zone: unknown zone "Local"`}},
+		{"every problem at once", "grpc_port: \"0\"\nlog_level: loud\nstore:\n  backend: sqlite\ntls:\n  key_file: k.pem\n", nil, nil,
+			[]string{"grpc_port", "log_level", "store.backend", "cert_file and key_file"}},
+		{"same ports", "grpc_port: \"9000\"\nhttp_port: \"9000\"\n", nil, nil, []string{"must differ"}},
+		{"non-positive duration", "auto_checkout:\n  interval: 0s\n", nil, nil, []string{"auto_checkout.interval"}},
+		{"drain delay past shutdown timeout", "shutdown_timeout: 10s\nshutdown_drain_delay: 10s\n", nil, nil, []string{"shutdown_drain_delay"}},
+		{"negative drain delay", "", nil, []string{"-shutdown-drain-delay", "-1s"}, []string{"shutdown_drain_delay"}},
+		{"mongo uri scheme", "store:\n  mongo_uri: http://db\n", nil, nil, []string{"store.mongo_uri"}},
+		{"mongo uri option", "", map[string]string{"MONGO_URI": "mongodb://db/?maxPoolSize=lots"}, nil, []string{"store.mongo_uri"}},
+		{"console exporter", "tracing:\n  exporter: console\n", nil, nil, []string{"tracing.exporter"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			args := append([]string{"-config", writeConfig(t, tc.yaml)}, tc.args...)
+			_, err := loadConfig(args)
+			if err == nil {
+				t.Fatal("loadConfig succeeded, want an error")
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Errorf("error %q does not mention %q", err, w)
+				}
+			}
+		})
+	}
+}
+
+func TestMongoURIs(t *testing.T) {
+	for _, uri := range []string{
+		"mongodb://u:p@[::1]:27017,h2/db",
+		"mongodb://a:27017,b",
+		"mongodb://localhost/?replicaSet=rs0",
+	} {
+		t.Run(uri, func(t *testing.T) {
+			if _, err := loadConfig([]string{"-mongo-uri", uri}); err != nil {
+				t.Errorf("loadConfig: %v", err)
+			}
+		})
+	}
+}
+
+func TestSettingMasksSecrets(t *testing.T) {
+	cfg := defaultConfig()
+	rendered := func(env string) string {
+		for _, s := range cfg.settings() {
+			if s.env == env {
+				return s.String()
+			}
+		}
+		t.Fatalf("no setting %s", env)
+		return ""
+	}
+
+	cfg.Auth.HMACSecret = "hunter2"
+	if got := rendered("JWT_HS256_SECRET"); got != "****" {
+		t.Errorf("JWT_HS256_SECRET renders as %q, want it masked", got)
+	}
+	cfg.Auth.HMACSecret = ""
+	if got := rendered("JWT_HS256_SECRET"); got != "" {
+		t.Errorf("empty secret renders as %q", got)
+	}
+
+	for _, tc := range []struct{ uri, want string }{
+		{"mongodb://localhost:27017", "mongodb://localhost:27017"},
+		{"mongodb://u:p%40ss@[::1]:27017,h2/db?authSource=admin", "mongodb://u:****@[::1]:27017,h2/db?authSource=admin"},
+		{"mongodb://u@h/?authMechanism=MONGODB-X509&tlsCertificateKeyFilePassword=pw", "mongodb://u@h/?authMechanism=MONGODB-X509&tlsCertificateKeyFilePassword=****"},
+		{"mongodb://h/?authMechanism=MONGODB-AWS&authMechanismProperties=AWS_SESSION_TOKEN:tok", "mongodb://h/?authMechanism=MONGODB-AWS&authMechanismProperties=****"},
+		{"mongodb://u:p@h:port", "****"}, // unparsed, so nothing of it is shown
+	} {
+		cfg.Store.MongoURI = tc.uri
+		if got := rendered("MONGO_URI"); got != tc.want {
+			t.Errorf("MONGO_URI %q renders as %q, want %q", tc.uri, got, tc.want)
+		}
+	}
+}
+
+func TestLoadZoneRejectsLocal(t *testing.T) {
+	if _, err := loadZone("Local"); err == nil {
+		t.Error(`loadZone("Local") succeeded; Mongo cannot use that name`)
+	}
+	if loc, err := loadZone("UTC"); err != nil || loc.String() != "UTC" {
+		t.Errorf(`loadZone("UTC") = %v, %v`, loc, err)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthMethods are served without authentication so probes need no token.
+var healthMethods = []string{
+	healthpb.Health_Check_FullMethodName,
+	healthpb.Health_List_FullMethodName,
+	healthpb.Health_Watch_FullMethodName,
+}
+
+// readiness drives the grpc.health.v1 status of the server (overall, "", and
+// per service) from periodic database pings.
+type readiness struct {
+	health   *health.Server
+	services []string
+	ping     func(ctx context.Context) error // nil when there is no database
+}
+
+func newReadiness(services []string, ping func(ctx context.Context) error) *readiness {
+	r := &readiness{health: health.NewServer(), services: services, ping: ping}
+	r.set(healthpb.HealthCheckResponse_NOT_SERVING) // until the first check
+	return r
+}
+
+func (r *readiness) set(st healthpb.HealthCheckResponse_ServingStatus) {
+	r.health.SetServingStatus("", st)
+	for _, svc := range r.services {
+		r.health.SetServingStatus(svc, st)
+	}
+}
+
+// check pings the database once and updates the serving status, logging
+// transitions.
+func (r *readiness) check(ctx context.Context, last *error) {
+	var err error
+	if r.ping != nil {
+		pctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		err = r.ping(pctx)
+		cancel()
+	}
+	if err != nil {
+		r.set(healthpb.HealthCheckResponse_NOT_SERVING)
+		if *last == nil {
+			slog.Warn("database unreachable, readiness NOT_SERVING", "error", err)
+		}
+	} else {
+		r.set(healthpb.HealthCheckResponse_SERVING)
+		if *last != nil {
+			slog.Info("database reachable again, readiness SERVING")
+		}
+	}
+	*last = err
+}
+
+// watch checks readiness now and then every interval until ctx is done.
+func (r *readiness) watch(ctx context.Context, interval time.Duration) {
+	var last error
+	r.check(ctx, &last)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			r.check(ctx, &last)
+		}
+	}
+}
+
+// shutdown reports NOT_SERVING from now on, ignoring later pings.
+func (r *readiness) shutdown() {
+	r.health.Shutdown()
+}
+
+// healthz is the liveness probe: the process is up and serving HTTP.
+func healthz(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	w.Write([]byte("ok\n"))
+}
+
+// readyz is the readiness probe, mirroring the overall gRPC health status.
+func (r *readiness) readyz(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+	resp, err := r.health.Check(req.Context(), &healthpb.HealthCheckRequest{})
+	if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	w.Write([]byte("ok\n"))
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestReadiness(t *testing.T) {
+	ctx := context.Background()
+	var pingErr error
+	r := newReadiness([]string{"attendance.AttendanceService"}, func(context.Context) error { return pingErr })
+
+	want := func(st healthpb.HealthCheckResponse_ServingStatus, code int) {
+		t.Helper()
+		for _, svc := range []string{"", "attendance.AttendanceService"} {
+			resp, err := r.health.Check(ctx, &healthpb.HealthCheckRequest{Service: svc})
+			if err != nil || resp.GetStatus() != st {
+				t.Errorf("service %q = %v, %v; want %v", svc, resp.GetStatus(), err, st)
+			}
+		}
+		rec := httptest.NewRecorder()
+		r.readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil), nil)
+		if rec.Code != code {
+			t.Errorf("/readyz = %d, want %d", rec.Code, code)
+		}
+	}
+
+	want(healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable) // before the first check
+	var last error
+	r.check(ctx, &last)
+	want(healthpb.HealthCheckResponse_SERVING, http.StatusOK)
+
+	pingErr = errors.New("connection refused")
+	r.check(ctx, &last)
+	want(healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable)
+	if last != pingErr {
+		t.Errorf("last = %v, want the ping error", last)
+	}
+
+	pingErr = nil
+	r.check(ctx, &last)
+	want(healthpb.HealthCheckResponse_SERVING, http.StatusOK)
+
+	// Shutdown wins over later successful pings.
+	r.shutdown()
+	want(healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable)
+	r.check(ctx, &last)
+	want(healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable)
+}
+
+func TestHealthz(t *testing.T) {
+	rec := httptest.NewRecorder()
+	healthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil), nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("/healthz = %d, want 200", rec.Code)
+	}
+}
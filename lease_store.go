@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// Mongo Model. A lease names the replica allowed to run a singleton
+// background job until ExpiresAt.
+type Lease struct {
+	Name      string    `bson:"_id"`
+	Holder    string    `bson:"holder"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// LeaseStore hands out time-limited leases so that only one replica runs a
+// job at a time. Expiry is judged by the caller's clock, so replicas' clocks
+// must agree to well within the ttl.
+type LeaseStore interface {
+	// Acquire takes or renews the named lease for holder until now+ttl. It
+	// reports false, without error, while another holder's lease is live.
+	Acquire(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error)
+	// Release gives up the lease if holder still has it.
+	Release(ctx context.Context, name, holder string) error
+}
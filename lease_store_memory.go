@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryLeaseStore keeps leases in process memory, for the single-process
+// memory backend.
+type memoryLeaseStore struct {
+	mu     sync.Mutex
+	leases map[string]Lease
+}
+
+func newMemoryLeaseStore() *memoryLeaseStore {
+	return &memoryLeaseStore{leases: map[string]Lease{}}
+}
+
+func (m *memoryLeaseStore) Acquire(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.leases[name]; ok && l.Holder != holder && now.Before(l.ExpiresAt) {
+		return false, nil
+	}
+	m.leases[name] = Lease{Name: name, Holder: holder, ExpiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (m *memoryLeaseStore) Release(ctx context.Context, name, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.leases[name]; ok && l.Holder == holder {
+		delete(m.leases, name)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLeaseStoreAcquire(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryLeaseStore()
+	ttl := 3 * time.Minute
+	acquire := func(holder string, now time.Time) bool {
+		t.Helper()
+		ok, err := m.Acquire(ctx, "job", holder, now, ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok
+	}
+
+	steps := []struct {
+		name   string
+		holder string
+		at     time.Duration // after t0
+		want   bool
+	}{
+		{"first holder takes it", "a", 0, true},
+		{"others wait while it is live", "b", time.Minute, false},
+		{"holder renews", "a", 2 * time.Minute, true},
+		{"renewal extends the expiry", "b", 4 * time.Minute, false},
+		{"taken once expired", "b", 5 * time.Minute, true},
+		{"old holder is now refused", "a", 6 * time.Minute, false},
+	}
+	for _, s := range steps {
+		if got := acquire(s.holder, t0.Add(s.at)); got != s.want {
+			t.Errorf("%s: Acquire(%s) = %v, want %v", s.name, s.holder, got, s.want)
+		}
+	}
+
+	// Only the holder can release.
+	if err := m.Release(ctx, "job", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if acquire("c", t0.Add(6*time.Minute)) {
+		t.Error("lease freed by a non-holder's Release")
+	}
+	if err := m.Release(ctx, "job", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if !acquire("c", t0.Add(6*time.Minute)) {
+		t.Error("lease not free after its holder released it")
+	}
+	if ok, err := m.Acquire(ctx, "other-job", "a", t0.Add(6*time.Minute), ttl); err != nil || !ok {
+		t.Errorf("a differently named lease: Acquire = %v, %v; want true", ok, err)
+	}
+}
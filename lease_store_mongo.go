@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoLeaseStore keeps leases in a MongoDB collection, one document per
+// lease name.
+type mongoLeaseStore struct {
+	collection *mongo.Collection
+}
+
+func newMongoLeaseStore(collection *mongo.Collection) *mongoLeaseStore {
+	return &mongoLeaseStore{collection: collection}
+}
+
+// Acquire updates the lease document if holder owns it or it has expired,
+// and inserts it if it does not exist. When another holder's lease is live
+// the filter matches nothing and the upsert collides on _id, which means the
+// lease is taken.
+func (m *mongoLeaseStore) Acquire(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"holder": holder},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"holder": holder, "expires_at": now.Add(ttl)}}
+	_, err := m.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *mongoLeaseStore) Release(ctx context.Context, name, holder string) error {
+	_, err := m.collection.DeleteOne(ctx, bson.M{"_id": name, "holder": holder})
+	return err
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// requestIDHeader is read from incoming metadata (forwarded by the gateway
+// from the X-Request-Id header) and echoed back in response headers.
+const requestIDHeader = "x-request-id"
+
+// redactedFields are personal fields replaced before a request is logged.
+var redactedFields = map[protoreflect.Name]bool{
+	"username": true,
+}
+
+// newLogger returns a JSON logger writing to stdout at the given level
+// (debug, info, warn or error).
+func newLogger(level string) (*slog.Logger, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, err
+	}
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: l})), nil
+}
+
+// fatal logs at error level and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// requestInfo identifies an RPC in logs. The auth interceptor fills in the
+// caller once it is known.
+type requestInfo struct {
+	id     string
+	caller string
+}
+
+type requestInfoKey struct{}
+
+func requestInfoFrom(ctx context.Context) (*requestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(*requestInfo)
+	return info, ok
+}
+
+// startRequest takes the request id from the incoming metadata, or generates
+// one, and returns a context carrying it.
+func startRequest(ctx context.Context) (context.Context, *requestInfo) {
+	info := &requestInfo{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(requestIDHeader); len(vals) > 0 && vals[0] != "" && len(vals[0]) <= 128 {
+		info.id = vals[0]
+	} else {
+		info.id = randomString(8, hex.EncodeToString)
+	}
+	return context.WithValue(ctx, requestInfoKey{}, info), info
+}
+
+// redacted renders a request for logging with personal fields masked.
+func redacted(req interface{}) slog.Value {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return slog.StringValue("")
+	}
+	m = proto.Clone(m)
+	redact(m.ProtoReflect())
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return slog.StringValue(err.Error())
+	}
+	var v map[string]any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return slog.StringValue(string(b))
+	}
+	return slog.AnyValue(v)
+}
+
+func redact(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case redactedFields[fd.Name()] && fd.Kind() == protoreflect.StringKind && !fd.IsList():
+			m.Set(fd, protoreflect.ValueOfString("[REDACTED]"))
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				redact(v.List().Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+			redact(v.Message())
+		}
+		return true
+	})
+}
+
+// logRPC writes one line per completed RPC. Server-side failures log at
+// error, other failures at warn, health checks at debug.
+func logRPC(ctx context.Context, method string, info *requestInfo, start time.Time, req interface{}, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+		for _, m := range healthMethods {
+			if m == method {
+				level = slog.LevelDebug
+			}
+		}
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+	if !slog.Default().Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.String("request_id", info.id),
+		slog.String("caller", info.caller),
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	if req != nil && slog.Default().Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request", redacted(req)))
+	}
+	slog.LogAttrs(ctx, level, "rpc", attrs...)
+}
+
+func loggingUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx, ri := startRequest(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, ri.id))
+		resp, err := handler(ctx, req)
+		logRPC(ctx, info.FullMethod, ri, start, req, err)
+		return resp, err
+	}
+}
+
+func loggingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, ri := startRequest(ss.Context())
+		ss.SetHeader(metadata.Pairs(requestIDHeader, ri.id))
+		rs := &recordingStream{ServerStream: ss, ctx: ctx}
+		err := handler(srv, rs)
+		logRPC(ctx, info.FullMethod, ri, start, rs.req, err)
+		return err
+	}
+}
+
+// recordingStream overrides the stream context and keeps the first message
+// received (the request of a server-streaming RPC) for logging.
+type recordingStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req interface{}
+}
+
+func (s *recordingStream) Context() context.Context { return s.ctx }
+
+func (s *recordingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.req == nil {
+		s.req = m
+	}
+	return err
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// captureLogs sends the default logger to a buffer at debug level for the
+// rest of the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+// rpcLine returns the single "rpc" line logged to buf.
+func rpcLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("log %q: %v", buf, err)
+	}
+	if line["msg"] != "rpc" {
+		t.Fatalf("log line = %v, want an rpc line", line)
+	}
+	return line
+}
+
+// transportStream records the headers a unary interceptor sets.
+type transportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// headerStream records the headers a stream interceptor sets.
+type headerStream struct {
+	grpc.ServerStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) Context() context.Context { return context.Background() }
+
+func TestLoggingUnaryInterceptor(t *testing.T) {
+	buf := captureLogs(t)
+	md := metadata.Pairs(requestIDHeader, "req-42", "authorization", "Bearer secret-token")
+	hs := &transportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), md), hs)
+
+	var seen string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		info, _ := requestInfoFrom(ctx)
+		seen = info.id
+		info.caller = "u1"
+		return nil, nil
+	}
+	req := &pb.CreateUserRequest{UserId: "u1", Username: "Alice Example"}
+	info := &grpc.UnaryServerInfo{FullMethod: pb.UserService_CreateUser_FullMethodName}
+	if _, err := loggingUnaryInterceptor()(ctx, req, info, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	if seen != "req-42" {
+		t.Errorf("handler saw request id %q, want req-42", seen)
+	}
+	if got := hs.header.Get(requestIDHeader); len(got) != 1 || got[0] != "req-42" {
+		t.Errorf("response header = %v, want req-42", got)
+	}
+	out := buf.String()
+	for _, secret := range []string{"Alice Example", "secret-token"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log contains %q: %s", secret, out)
+		}
+	}
+	line := rpcLine(t, buf)
+	if line["request_id"] != "req-42" || line["caller"] != "u1" || line["code"] != "OK" {
+		t.Errorf("log line = %v, want request id req-42, caller u1, code OK", line)
+	}
+	logged, _ := line["request"].(map[string]any)
+	if logged["userId"] != "u1" || logged["username"] != "[REDACTED]" {
+		t.Errorf("logged request = %v, want user id kept and username redacted", logged)
+	}
+}
+
+func TestLoggingStreamInterceptorGeneratesRequestID(t *testing.T) {
+	buf := captureLogs(t)
+	stream := &headerStream{}
+
+	var seen string
+	handler := func(_ interface{}, ss grpc.ServerStream) error {
+		info, _ := requestInfoFrom(ss.Context())
+		seen = info.id
+		return nil
+	}
+	info := &grpc.StreamServerInfo{FullMethod: pb.AttendanceService_StreamAttendance_FullMethodName}
+	if err := loggingStreamInterceptor()(nil, stream, info, handler); err != nil {
+		t.Fatal(err)
+	}
+	if seen == "" {
+		t.Fatal("handler saw no request id")
+	}
+	if id := rpcLine(t, buf)["request_id"]; id != seen {
+		t.Errorf("logged request id %v, handler saw %q", id, seen)
+	}
+	if got := stream.header.Get(requestIDHeader); len(got) != 1 || got[0] != seen {
+		t.Errorf("response header = %v, want %q", got, seen)
+	}
+}
+
+func TestRedactNested(t *testing.T) {
+	resp := &pb.GetDailyReportResponse{Users: []*pb.UserDayReport{{UserId: "u1", Username: "Alice Example"}}}
+	b, err := json.Marshal(redacted(resp).Any())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "Alice Example") || !strings.Contains(string(b), "[REDACTED]") {
+		t.Errorf("redacted = %s, want the nested username masked", b)
+	}
+	if resp.GetUsers()[0].GetUsername() != "Alice Example" {
+		t.Error("redacted changed the original message")
+	}
+}
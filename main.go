@@ -2,70 +2,339 @@ package main
 
 import (
 	"context"
-	"log"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	pb "attendance1/proto"
 )
 
 func main() {
-	log.Println("Starting Attendance Service (gRPC + REST)")
-
-	// MongoDB
-	mongoURI := getEnv("MONGO_URI", "mongodb://localhost:27017")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	cfg, err := loadConfig(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid configuration:")
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	logger, _ := newLogger(cfg.LogLevel) // validated
+	slog.SetDefault(logger)
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	slog.Info("Starting Attendance Service (gRPC + REST)")
+	cfg.logConfig()
+	shutdownTracing, err := setupTracing(context.Background(), cfg.Tracing.Exporter)
 	if err != nil {
-		log.Fatal("Mongo connect error:", err)
+		fatal("Tracing setup error", "error", err)
+	}
+
+	// Storage
+	var store AttendanceStore
+	var users UserStore
+	var apiKeys APIKeyStore
+	var shifts ShiftStore
+	var leases LeaseStore
+	var mongoClient *mongo.Client
+	switch cfg.Store.Backend {
+	case "memory":
+		store = newMemoryStore()
+		users = newMemoryUserStore()
+		apiKeys = newMemoryAPIKeyStore()
+		shifts = newMemoryShiftStore()
+		leases = newMemoryLeaseStore()
+		slog.Info("Using in-memory store")
+	case "mongo":
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Store.ConnectTimeout)
+		defer cancel()
+
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.Store.MongoURI).SetMonitor(combineMonitors(mongoMonitor(), otelmongo.NewMonitor())))
+		if err != nil {
+			fatal("Mongo connect error", "error", err)
+		}
+		mongoClient = client
+		// Connect does not talk to the server; fail fast if it is unreachable.
+		if err := client.Ping(ctx, readpref.Primary()); err != nil {
+			fatal("Mongo ping error", "error", err)
+		}
+		slog.Info("MongoDB connected successfully")
+
+		db := client.Database(cfg.Store.Database)
+		store, err = newMongoStore(ctx, db.Collection(cfg.Store.RecordsCollection))
+		if err != nil {
+			fatal("Mongo index setup error", "error", err)
+		}
+		mongoUsers := newMongoUserStore(db.Collection(cfg.Store.UsersCollection))
+		users = mongoUsers
+		// CheckIn requires registered users; on the first start with the
+		// registry, register everyone who already has attendance records.
+		if n, err := mongoUsers.collection.EstimatedDocumentCount(ctx); err != nil {
+			fatal("Mongo users count error", "error", err)
+		} else if n == 0 {
+			sctx, scancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			added, err := mongoUsers.seedFromRecords(sctx, db.Collection(cfg.Store.RecordsCollection), time.Now().UTC())
+			scancel()
+			if err != nil {
+				fatal("Seeding users from records failed", "error", err)
+			}
+			slog.Info("Seeded user registry from attendance records", "users", added)
+		}
+		apiKeys = newMongoAPIKeyStore(db.Collection(cfg.Store.APIKeysCollection))
+		shifts = newMongoShiftStore(db.Collection(cfg.Store.ShiftsCollection))
+		leases = newMongoLeaseStore(db.Collection(cfg.Store.LeasesCollection))
+	}
+	loc, _ := loadZone(cfg.TimeZone) // validated
+	registerStoreGauges(store)
+
+	// Authentication (kiosk API keys are always honoured)
+	auth := &authInterceptor{keys: &apiKeyAuthenticator{keys: apiKeys}, public: map[string]bool{}}
+	for _, m := range healthMethods {
+		auth.public[m] = true
+	}
+	if cfg.Auth.enabled() {
+		auth.jwt, err = newJWTAuthenticator(cfg.Auth)
+		if err != nil {
+			fatal("Auth setup error", "error", err)
+		}
+		slog.Info("JWT authentication enabled")
+	} else {
+		slog.Warn("No JWT keys configured, authentication disabled")
 	}
-	log.Println("MongoDB connected successfully")
 
-	collection := client.Database("attendance_db").Collection("records")
-	loc, _ := time.LoadLocation("Asia/Kolkata")
+	// TLS (optional; mTLS on gRPC when a CA is given)
+	var certs *certReloader
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	clientAuth, _ := parseClientAuth(cfg.TLS.ClientAuth) // validated
+	if cfg.TLS.CertFile != "" {
+		certs, err = newCertReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.CAFile)
+		if err != nil {
+			fatal("TLS setup error", "error", err)
+		}
+		go certs.watch(watchCtx, cfg.TLS.ReloadInterval)
+		slog.Info("TLS enabled")
+	}
 
 	// gRPC Server
-	grpcPort := getEnv("GRPC_PORT", "50052")
-	grpcServer := grpc.NewServer()
-	s := &attendanceServer{collection: collection, loc: loc}
+	grpcPort := cfg.GRPCPort
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.ChainUnaryInterceptor(loggingUnaryInterceptor(), metricsUnaryInterceptor(), auth.unaryInterceptor()),
+		grpc.ChainStreamInterceptor(loggingStreamInterceptor(), metricsStreamInterceptor(), auth.streamInterceptor()),
+	}
+	if certs != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(certs.serverConfig(clientAuth, []string{"h2"}))))
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
+	policy := &accessPolicy{users: users}
+	s := &attendanceServer{store: store, users: users, shifts: shifts, policy: policy, loc: loc}
 	pb.RegisterAttendanceServiceServer(grpcServer, s)
+	pb.RegisterUserServiceServer(grpcServer, &userServer{users: users, policy: policy})
+	pb.RegisterApiKeyServiceServer(grpcServer, &apiKeyServer{keys: apiKeys, policy: policy})
+	pb.RegisterShiftServiceServer(grpcServer, &shiftServer{shifts: shifts, users: users, policy: policy})
+
+	// Health: ready while MongoDB answers pings (always, for the memory store)
+	var ping func(ctx context.Context) error
+	if mongoClient != nil {
+		ping = func(ctx context.Context) error { return mongoClient.Ping(ctx, readpref.Primary()) }
+	}
+	ready := newReadiness([]string{
+		pb.AttendanceService_ServiceDesc.ServiceName,
+		pb.UserService_ServiceDesc.ServiceName,
+		pb.ApiKeyService_ServiceDesc.ServiceName,
+		pb.ShiftService_ServiceDesc.ServiceName,
+	}, ping)
+	healthpb.RegisterHealthServer(grpcServer, ready.health)
+	readyCtx, stopReadiness := context.WithCancel(context.Background())
+	defer stopReadiness()
+	go ready.watch(readyCtx, cfg.Store.PingInterval)
+
+	// Auto checkout of forgotten sessions (one replica at a time)
+	sweepCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	sweeperDone := make(chan struct{})
+	if cfg.AutoCheckout.Enabled {
+		sweeper := newAutoCheckout(store, leases, cfg.AutoCheckout.Interval, cfg.AutoCheckout.MaxSession, cfg.AutoCheckout.AfterShiftEnd)
+		go func() {
+			sweeper.run(sweepCtx)
+			close(sweeperDone)
+		}()
+		slog.Info("Auto checkout enabled", "holder", sweeper.holder)
+	} else {
+		close(sweeperDone)
+	}
 
 	lis, err := net.Listen("tcp", ":"+grpcPort)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		fatal("Failed to listen", "error", err)
 	}
 
+	// Servers report fatal errors here; SIGTERM/SIGINT start a graceful stop.
+	serveErr := make(chan error, 2)
+	sigCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stopSignals()
+
 	go func() {
-		log.Println("gRPC server running on port", grpcPort)
+		slog.Info("gRPC server running", "port", grpcPort)
 		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("Failed to serve gRPC: %v", err)
+			serveErr <- fmt.Errorf("serve gRPC: %w", err)
 		}
 	}()
 
-	// REST Gateway
-	httpPort := getEnv("HTTP_PORT", "8080")
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithInsecure()}
-	err = pb.RegisterAttendanceServiceHandlerFromEndpoint(context.Background(), mux, "localhost:"+grpcPort, opts)
-	if err != nil {
-		log.Fatalf("Failed to start HTTP gateway: %v", err)
+	// REST Gateway (forwards the Authorization, X-Api-Key and X-Request-Id
+	// headers as gRPC metadata and returns X-Request-Id)
+	httpPort := cfg.HTTPPort
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
+	dialCreds := insecure.NewCredentials()
+	if certs != nil {
+		dialCreds = credentials.NewTLS(certs.clientConfig(cfg.TLS.ServerName))
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(dialCreds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()), // propagates trace context to gRPC
+	}
+	// The gateway's connection to gRPC is closed when gwCtx is cancelled.
+	gwCtx, stopGateway := context.WithCancel(context.Background())
+	defer stopGateway()
+	if err := pb.RegisterAttendanceServiceHandlerFromEndpoint(gwCtx, mux, "localhost:"+grpcPort, opts); err != nil {
+		fatal("Failed to start HTTP gateway", "error", err)
+	}
+	if err := pb.RegisterUserServiceHandlerFromEndpoint(gwCtx, mux, "localhost:"+grpcPort, opts); err != nil {
+		fatal("Failed to start HTTP gateway", "error", err)
+	}
+	if err := pb.RegisterApiKeyServiceHandlerFromEndpoint(gwCtx, mux, "localhost:"+grpcPort, opts); err != nil {
+		fatal("Failed to start HTTP gateway", "error", err)
+	}
+	if err := pb.RegisterShiftServiceHandlerFromEndpoint(gwCtx, mux, "localhost:"+grpcPort, opts); err != nil {
+		fatal("Failed to start HTTP gateway", "error", err)
+	}
+	if err := mux.HandlePath("GET", "/healthz", healthz); err != nil {
+		fatal("Failed to register /healthz", "error", err)
+	}
+	if err := mux.HandlePath("GET", "/readyz", ready.readyz); err != nil {
+		fatal("Failed to register /readyz", "error", err)
+	}
+	metrics := promhttp.Handler()
+	if err := mux.HandlePath("GET", "/metrics", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		metrics.ServeHTTP(w, r)
+	}); err != nil {
+		fatal("Failed to register /metrics", "error", err)
+	}
+	handler := otelhttp.NewHandler(mux, "gateway",
+		otelhttp.WithFilter(tracedPath),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string { return "gateway " + r.Method }),
+	)
+	httpServer := &http.Server{Addr: ":" + httpPort, Handler: handler}
+	go func() {
+		slog.Info("REST gateway running", "port", httpPort)
+		var err error
+		if certs != nil {
+			// Plain HTTPS: browsers, kiosks and kubelet probes have no
+			// client certificate. mTLS applies to the gRPC port only.
+			httpServer.TLSConfig = certs.serverConfig(tls.NoClientCert, []string{"h2", "http/1.1"})
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != http.ErrServerClosed {
+			serveErr <- fmt.Errorf("serve HTTP: %w", err)
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		fatal("Server failed", "error", err)
+	case <-sigCtx.Done():
+		stopSignals() // a second signal kills the process immediately
+	}
+
+	stopReadiness()
+	ready.shutdown()
+	stopSweeper()
+	<-sweeperDone // releases the lease so another replica takes over at once
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	// Keep serving while load balancers and kube-proxy notice the failed
+	// readiness probe; the delay comes out of the shutdown timeout.
+	slog.Info("Shutting down, waiting for traffic to move away", "delay", cfg.DrainDelay.String())
+	time.Sleep(cfg.DrainDelay)
+	slog.Info("Draining in-flight requests", "timeout", (cfg.ShutdownTimeout - cfg.DrainDelay).String())
+
+	// REST first: its requests are still being served by gRPC underneath.
+	if err := httpServer.Shutdown(ctx); err != nil {
+		slog.Warn("HTTP shutdown", "error", err)
+	}
+	stopGateway()
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Warn("Drain deadline exceeded, cancelling remaining RPCs")
+		grpcServer.Stop()
+		<-stopped
+	}
+
+	stopWatch()
+
+	if mongoClient != nil {
+		// Disconnect gets its own short deadline so it still runs after a
+		// slow drain.
+		dctx, dcancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer dcancel()
+		if err := mongoClient.Disconnect(dctx); err != nil {
+			slog.Warn("Mongo disconnect", "error", err)
+		}
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer tcancel()
+	if err := shutdownTracing(tctx); err != nil {
+		slog.Warn("Tracing shutdown", "error", err)
+	}
+	slog.Info("Shutdown complete")
+}
+
+// headerMatcher forwards X-Api-Key and X-Request-Id in addition to the
+// gateway's defaults.
+func headerMatcher(key string) (string, bool) {
+	switch http.CanonicalHeaderKey(key) {
+	case "X-Api-Key":
+		return apiKeyHeader, true
+	case "X-Request-Id":
+		return requestIDHeader, true
 	}
-	log.Println("REST gateway running on port", httpPort)
-	log.Fatal(http.ListenAndServe(":"+httpPort, mux))
+	return runtime.DefaultHeaderMatcher(key)
 }
 
-func getEnv(key, def string) string {
-	if v := os.Getenv(key); v != "" {
-		return v
+// outgoingHeaderMatcher returns the request id as a plain X-Request-Id
+// header; other response metadata keeps the gateway's Grpc-Metadata- prefix.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == requestIDHeader {
+		return "X-Request-Id", true
 	}
-	return def
+	return runtime.MetadataHeaderPrefix + key, true
 }
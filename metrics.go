@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Prometheus metrics, served on the gateway port at /metrics.
+var (
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "attendance_rpc_duration_seconds",
+		Help:    "Latency of gRPC calls (including those from the REST gateway) by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+	rpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "attendance_rpc_requests_total",
+		Help: "Completed gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	mongoDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "attendance_mongo_command_duration_seconds",
+		Help:    "Latency of MongoDB commands by command name and outcome.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"command", "outcome"})
+
+	checkIns = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attendance_checkins_total",
+		Help: "Successful check-ins; rate() gives check-ins per minute.",
+	})
+	checkOuts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attendance_checkouts_total",
+		Help: "Successful check-outs.",
+	})
+	duplicateCheckIns = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attendance_duplicate_checkins_total",
+		Help: "Check-ins rejected because the user already had an open session.",
+	})
+	autoCheckOuts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "attendance_auto_checkouts_total",
+		Help: "Forgotten sessions closed by the auto-checkout sweeper, by reason.",
+	}, []string{"reason"})
+)
+
+// registerStoreGauges exports store-wide gauges computed at scrape time, so
+// every replica reports the same value.
+func registerStoreGauges(store AttendanceStore) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "attendance_checked_in_users",
+		Help: "Users currently checked in (open sessions).",
+	}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		n, err := store.Count(ctx, RecordFilter{State: OpenSession})
+		if err != nil {
+			slog.Warn("metrics: counting open sessions failed", "error", err)
+			return math.NaN()
+		}
+		return float64(n)
+	})
+}
+
+func observeRPC(method string, start time.Time, err error) {
+	rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	rpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+func metricsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func metricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+// mongoMonitor records the latency of every MongoDB command.
+func mongoMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			mongoDuration.WithLabelValues(e.CommandName, "success").Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			mongoDuration.WithLabelValues(e.CommandName, "failure").Observe(e.Duration.Seconds())
+		},
+	}
+}
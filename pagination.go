@@ -0,0 +1,154 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "attendance1/proto"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+	dateLayout      = "2006-01-02"
+)
+
+// errPageTokenMismatch is returned by decodePageToken for a token issued
+// for a different sort order or filters.
+var errPageTokenMismatch = errors.New("page token belongs to a different query")
+
+// pageToken is the JSON payload behind the opaque page_token string. A
+// cursor is only meaningful for the query it came from, so the token also
+// records the sort order and a hash of the filters.
+type pageToken struct {
+	CheckinTime int64  `json:"t"` // unix nanoseconds
+	ID          string `json:"id"`
+	Ascending   bool   `json:"asc,omitempty"`
+	Filter      string `json:"f"`
+}
+
+// filterHash identifies a RecordFilter in page tokens.
+func filterHash(f RecordFilter) string {
+	var from, to int64
+	if !f.From.IsZero() {
+		from = f.From.UnixNano()
+	}
+	if !f.To.IsZero() {
+		to = f.To.UnixNano()
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%q|%d|%d|%d", f.UserID, from, to, f.State)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func encodePageToken(c RecordCursor, q RecordQuery) string {
+	b, _ := json.Marshal(pageToken{
+		CheckinTime: c.CheckinTime.UnixNano(),
+		ID:          c.ID.Hex(),
+		Ascending:   q.Ascending,
+		Filter:      filterHash(q.RecordFilter),
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken returns the cursor in tok, or errPageTokenMismatch if tok
+// was issued for a query other than q.
+func decodePageToken(tok string, q RecordQuery) (*RecordCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(tok)
+	if err != nil {
+		return nil, err
+	}
+	var pt pageToken
+	if err := json.Unmarshal(b, &pt); err != nil {
+		return nil, err
+	}
+	id, err := primitive.ObjectIDFromHex(pt.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pt.Ascending != q.Ascending || pt.Filter != filterHash(q.RecordFilter) {
+		return nil, errPageTokenMismatch
+	}
+	return &RecordCursor{CheckinTime: time.Unix(0, pt.CheckinTime).UTC(), ID: id}, nil
+}
+
+// pageSize applies the default and maximum to a requested page size.
+func pageSize(size int32) (int, error) {
+	switch {
+	case size < 0:
+		return 0, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case size == 0:
+		return defaultPageSize, nil
+	case size > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int(size), nil
+}
+
+// listQuery validates a ListAttendanceRequest and turns it into a store query.
+func listQuery(req *pb.ListAttendanceRequest, loc *time.Location) (RecordQuery, error) {
+	f, err := recordFilter(loc, req.GetUserId(), req.GetStartDate(), req.GetEndDate(), req.GetStatus())
+	if err != nil {
+		return RecordQuery{}, err
+	}
+	q := RecordQuery{
+		RecordFilter: f,
+		Ascending:    req.GetSort() == pb.SortOrder_SORT_ORDER_OLDEST_FIRST,
+	}
+
+	if q.Limit, err = pageSize(req.GetPageSize()); err != nil {
+		return q, err
+	}
+
+	if tok := req.GetPageToken(); tok != "" {
+		c, err := decodePageToken(tok, q)
+		if err == errPageTokenMismatch {
+			return q, status.Error(codes.InvalidArgument, "page_token was issued for a different sort or filters")
+		}
+		if err != nil {
+			return q, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		q.After = c
+	}
+	return q, nil
+}
+
+// recordFilter validates the filter fields shared by ListAttendance and
+// StreamAttendance. Dates are whole days in loc.
+func recordFilter(loc *time.Location, userID, startDate, endDate string, st pb.SessionStatus) (RecordFilter, error) {
+	f := RecordFilter{UserID: userID}
+
+	if startDate != "" {
+		t, err := time.ParseInLocation(dateLayout, startDate, loc)
+		if err != nil {
+			return f, status.Error(codes.InvalidArgument, "start_date must be YYYY-MM-DD")
+		}
+		f.From = t
+	}
+	if endDate != "" {
+		t, err := time.ParseInLocation(dateLayout, endDate, loc)
+		if err != nil {
+			return f, status.Error(codes.InvalidArgument, "end_date must be YYYY-MM-DD")
+		}
+		f.To = t.AddDate(0, 0, 1)
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return f, status.Error(codes.InvalidArgument, "start_date must not be after end_date")
+	}
+
+	switch st {
+	case pb.SessionStatus_SESSION_STATUS_OPEN:
+		f.State = OpenSession
+	case pb.SessionStatus_SESSION_STATUS_CLOSED:
+		f.State = ClosedSession
+	}
+	return f, nil
+}
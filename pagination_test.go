@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "attendance1/proto"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+)
+
+func TestMemoryStoreListAfter(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryStore()
+	a := insertRecord(t, m, "u1", t0, t0.Add(time.Hour))
+	b := insertRecord(t, m, "u2", t0.Add(time.Hour), time.Time{})
+	c := insertRecord(t, m, "u3", t0.Add(time.Hour), time.Time{}) // same checkin as b
+	d := insertRecord(t, m, "u1", t0.Add(2*time.Hour), time.Time{})
+	// ObjectIDs break ties on checkin_time; b sorts before c.
+	if b.ID.Hex() > c.ID.Hex() {
+		b, c = c, b
+	}
+	cursor := func(r *AttendanceRecord) *RecordCursor {
+		return &RecordCursor{CheckinTime: r.CheckinTime, ID: r.ID}
+	}
+
+	for _, tc := range []struct {
+		name string
+		q    RecordQuery
+		want []*AttendanceRecord
+	}{
+		{"ascending", RecordQuery{Ascending: true}, []*AttendanceRecord{a, b, c, d}},
+		{"descending", RecordQuery{}, []*AttendanceRecord{d, c, b, a}},
+		{"ascending after tie", RecordQuery{Ascending: true, After: cursor(b)}, []*AttendanceRecord{c, d}},
+		{"descending after tie", RecordQuery{After: cursor(c)}, []*AttendanceRecord{b, a}},
+		{"ascending after last", RecordQuery{Ascending: true, After: cursor(d)}, nil},
+		{"descending after last", RecordQuery{After: cursor(a)}, nil},
+		{"limit", RecordQuery{Ascending: true, After: cursor(a), Limit: 2}, []*AttendanceRecord{b, c}},
+		{"filtered", RecordQuery{RecordFilter: RecordFilter{UserID: "u1"}, After: cursor(d)}, []*AttendanceRecord{a}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := m.List(ctx, tc.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d records, want %d", len(got), len(tc.want))
+			}
+			for i := range got {
+				if got[i].ID != tc.want[i].ID {
+					t.Errorf("record %d is %s (%s), want %s (%s)", i, got[i].ID.Hex(), got[i].UserID, tc.want[i].ID.Hex(), tc.want[i].UserID)
+				}
+			}
+		})
+	}
+}
+
+// listAll pages through ListAttendance and returns the record ids in order.
+func listAll(t *testing.T, s *attendanceServer, req *pb.ListAttendanceRequest) []string {
+	t.Helper()
+	var ids []string
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("too many pages")
+		}
+		resp, err := s.ListAttendance(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range resp.GetRecords() {
+			ids = append(ids, r.GetId())
+		}
+		if resp.GetNextPageToken() == "" {
+			return ids
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+func TestListAttendancePaging(t *testing.T) {
+	s := newTestServer(t)
+	m := s.store.(*memoryStore)
+	for i := 0; i < 5; i++ {
+		// Two records share each checkin time.
+		in := t0.Add(time.Duration(i/2) * time.Hour)
+		insertRecord(t, m, "u1", in, in.Add(time.Minute))
+	}
+	all, _ := m.List(context.Background(), RecordQuery{Ascending: true})
+	var asc []string
+	for _, r := range all {
+		asc = append(asc, r.ID.Hex())
+	}
+
+	got := listAll(t, s, &pb.ListAttendanceRequest{PageSize: 2, Sort: pb.SortOrder_SORT_ORDER_OLDEST_FIRST})
+	if len(got) != len(asc) {
+		t.Fatalf("ascending: got %d records, want %d", len(got), len(asc))
+	}
+	for i := range got {
+		if got[i] != asc[i] {
+			t.Errorf("ascending record %d = %s, want %s", i, got[i], asc[i])
+		}
+	}
+	got = listAll(t, s, &pb.ListAttendanceRequest{PageSize: 2})
+	for i := range got {
+		if want := asc[len(asc)-1-i]; got[i] != want {
+			t.Errorf("descending record %d = %s, want %s", i, got[i], want)
+		}
+	}
+}
+
+func TestListAttendancePageTokenMismatch(t *testing.T) {
+	s := newTestServer(t)
+	m := s.store.(*memoryStore)
+	for i := 0; i < 3; i++ {
+		insertRecord(t, m, "u1", t0.Add(time.Duration(i)*time.Hour), time.Time{})
+	}
+	first, err := s.ListAttendance(context.Background(), &pb.ListAttendanceRequest{PageSize: 1, UserId: "u1"})
+	if err != nil || first.GetNextPageToken() == "" {
+		t.Fatalf("first page: %v, %v", first, err)
+	}
+	tok := first.GetNextPageToken()
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.ListAttendanceRequest
+		code codes.Code
+	}{
+		{"same query", &pb.ListAttendanceRequest{PageSize: 1, UserId: "u1", PageToken: tok}, codes.OK},
+		{"page size may change", &pb.ListAttendanceRequest{PageSize: 5, UserId: "u1", PageToken: tok}, codes.OK},
+		{"other sort", &pb.ListAttendanceRequest{UserId: "u1", PageToken: tok, Sort: pb.SortOrder_SORT_ORDER_OLDEST_FIRST}, codes.InvalidArgument},
+		{"other user", &pb.ListAttendanceRequest{UserId: "u2", PageToken: tok}, codes.InvalidArgument},
+		{"other status", &pb.ListAttendanceRequest{UserId: "u1", PageToken: tok, Status: pb.SessionStatus_SESSION_STATUS_OPEN}, codes.InvalidArgument},
+		{"other dates", &pb.ListAttendanceRequest{UserId: "u1", PageToken: tok, StartDate: "2026-03-01"}, codes.InvalidArgument},
+		{"garbage", &pb.ListAttendanceRequest{UserId: "u1", PageToken: "!!"}, codes.InvalidArgument},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.ListAttendance(context.Background(), tc.req)
+			wantCode(t, err, tc.code)
+		})
+	}
+}
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	q := RecordQuery{RecordFilter: RecordFilter{UserID: "u1", From: t0}, Ascending: true}
+	c := RecordCursor{CheckinTime: t0.Add(90 * time.Second), ID: primitive.NewObjectID()}
+	got, err := decodePageToken(encodePageToken(c, q), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.CheckinTime.Equal(c.CheckinTime) || got.ID != c.ID {
+		t.Errorf("decoded %+v, want %+v", got, c)
+	}
+}
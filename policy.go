@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles carried in the JWT "role"/"roles" claims.
+const (
+	RoleEmployee = "employee"
+	RoleManager  = "manager"
+	RoleAdmin    = "admin"
+	// RoleKiosk is given to API key callers, never read from a JWT.
+	RoleKiosk = "kiosk"
+)
+
+// accessPolicy decides what an authenticated caller may do:
+//   - employees act on and read only their own user_id
+//   - managers additionally read users whose manager_id is the caller
+//   - admins may do everything
+//   - kiosks (API keys) check users in and out, but only touch sessions
+//     opened at their own site; the auth interceptor limits them to the
+//     methods their key is scoped to
+//
+// When authentication is disabled there is no Principal in the context and
+// every call is allowed.
+type accessPolicy struct {
+	users UserStore
+}
+
+// unrestricted reports whether the caller may act on every record: admins,
+// and everyone when authentication is disabled.
+func (p *accessPolicy) unrestricted(ctx context.Context) bool {
+	caller, ok := principalFrom(ctx)
+	return !ok || caller.HasRole(RoleAdmin)
+}
+
+// canActAs allows writes (check in/out) on userID's records.
+func (p *accessPolicy) canActAs(ctx context.Context, userID string) error {
+	caller, ok := principalFrom(ctx)
+	if !ok || caller.HasRole(RoleAdmin) || caller.HasRole(RoleKiosk) || caller.Subject == userID {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "caller %q may only act on their own user_id, not %q", caller.Subject, userID)
+}
+
+// canActAtSite allows writes on rec only from its own site: callers bound to
+// a site (kiosks) may not close or break sessions opened elsewhere.
+func (p *accessPolicy) canActAtSite(ctx context.Context, rec *AttendanceRecord) error {
+	caller, ok := principalFrom(ctx)
+	if !ok || caller.Site == "" || rec.Site == caller.Site {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "record %s was not opened at site %q", rec.ID.Hex(), caller.Site)
+}
+
+// canRead allows reading userID's records and profile.
+func (p *accessPolicy) canRead(ctx context.Context, userID string) error {
+	caller, ok := principalFrom(ctx)
+	if !ok || caller.HasRole(RoleAdmin) || caller.Subject == userID {
+		return nil
+	}
+	if caller.HasRole(RoleManager) {
+		u, err := p.users.Get(ctx, userID)
+		if err == nil && u.ManagerID == caller.Subject {
+			return nil
+		}
+		if err != nil && err != ErrNotFound {
+			return status.Errorf(codes.Internal, "db error: %v", err)
+		}
+		return status.Errorf(codes.PermissionDenied, "user %q does not report to manager %q", userID, caller.Subject)
+	}
+	return status.Errorf(codes.PermissionDenied, "caller %q may only read their own records, not %q", caller.Subject, userID)
+}
+
+// requireAdmin allows the action only for admins.
+func (p *accessPolicy) requireAdmin(ctx context.Context, action string) error {
+	caller, ok := principalFrom(ctx)
+	if !ok || caller.HasRole(RoleAdmin) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "%s requires the %q role", action, RoleAdmin)
+}
+
+// managerScope returns the manager whose reports a caller may see in
+// organization-wide views: "" for admins (everyone), the caller's own id for
+// managers. Other callers are denied.
+func (p *accessPolicy) managerScope(ctx context.Context, action string) (string, error) {
+	caller, ok := principalFrom(ctx)
+	if !ok || caller.HasRole(RoleAdmin) {
+		return "", nil
+	}
+	if caller.HasRole(RoleManager) {
+		return caller.Subject, nil
+	}
+	return "", status.Errorf(codes.PermissionDenied, "%s requires the %q or %q role", action, RoleManager, RoleAdmin)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+func kioskContext(site string) context.Context {
+	return withPrincipal(context.Background(), &Principal{Subject: "apikey:" + site, Roles: []string{RoleKiosk}, Site: site})
+}
+
+func TestKioskSiteScoping(t *testing.T) {
+	s := newTestServer(t, "u1")
+	siteA, siteB := kioskContext("A"), kioskContext("B")
+
+	in, err := s.CheckIn(siteA, &pb.CheckInRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in.GetSite() != "A" {
+		t.Errorf("site = %q, want A", in.GetSite())
+	}
+
+	for _, tc := range []struct {
+		name string
+		call func(context.Context) error
+	}{
+		{"CheckOut", func(ctx context.Context) error {
+			_, err := s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: in.GetId()})
+			return err
+		}},
+		{"CheckOutUser", func(ctx context.Context) error {
+			_, err := s.CheckOutUser(ctx, &pb.CheckOutUserRequest{UserId: "u1"})
+			return err
+		}},
+		{"StartBreak", func(ctx context.Context) error {
+			_, err := s.StartBreak(ctx, &pb.StartBreakRequest{UserId: "u1"})
+			return err
+		}},
+		{"EndBreak", func(ctx context.Context) error {
+			_, err := s.EndBreak(ctx, &pb.EndBreakRequest{UserId: "u1"})
+			return err
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			wantCode(t, tc.call(siteB), codes.PermissionDenied)
+		})
+	}
+
+	// The session is untouched and its own site can still close it.
+	if _, err := s.StartBreak(siteA, &pb.StartBreakRequest{UserId: "u1"}); err != nil {
+		t.Errorf("StartBreak from site A: %v", err)
+	}
+	if _, err := s.EndBreak(siteA, &pb.EndBreakRequest{UserId: "u1"}); err != nil {
+		t.Errorf("EndBreak from site A: %v", err)
+	}
+	if _, err := s.CheckOutUser(siteA, &pb.CheckOutUserRequest{UserId: "u1"}); err != nil {
+		t.Errorf("CheckOutUser from site A: %v", err)
+	}
+	_, err = s.CheckOutUser(siteA, &pb.CheckOutUserRequest{UserId: "u1"})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestCanActAtSite(t *testing.T) {
+	user := withPrincipal(context.Background(), &Principal{Subject: "u1", Roles: []string{RoleEmployee}})
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		site string
+		ok   bool
+	}{
+		{"no principal", context.Background(), "A", true},
+		{"caller without site", user, "A", true},
+		{"same site", kioskContext("A"), "A", true},
+		{"other site", kioskContext("B"), "A", false},
+		{"record without site", kioskContext("A"), "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&accessPolicy{}).canActAtSite(tc.ctx, &AttendanceRecord{Site: tc.site})
+			if tc.ok && err != nil {
+				t.Errorf("canActAtSite: %v", err)
+			}
+			if !tc.ok {
+				wantCode(t, err, codes.PermissionDenied)
+			}
+		})
+	}
+}
+
+func TestRoleAccess(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "m1", "e1", "e2")
+	for id, mgr := range map[string]string{"e1": "m1", "e2": "m2"} {
+		mgr := mgr
+		if _, err := s.users.Update(ctx, id, UserUpdate{ManagerID: &mgr}, t0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r1, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "e1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "e2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	employee := withPrincipal(ctx, &Principal{Subject: "e1", Roles: []string{RoleEmployee}})
+	manager := withPrincipal(ctx, &Principal{Subject: "m1", Roles: []string{RoleManager}})
+	admin := withPrincipal(ctx, &Principal{Subject: "root", Roles: []string{RoleAdmin}})
+	missing := "000000000000000000000000"
+
+	list := func(userID string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := s.ListAttendance(ctx, &pb.ListAttendanceRequest{UserId: userID})
+			return err
+		}
+	}
+	summary := func(userID string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := s.GetUserSummary(ctx, &pb.GetUserSummaryRequest{UserId: userID})
+			return err
+		}
+	}
+	checkOut := func(id string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: id})
+			return err
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		call func(context.Context) error
+		code codes.Code
+	}{
+		{"employee lists own", employee, list("e1"), codes.OK},
+		{"employee lists another", employee, list("e2"), codes.PermissionDenied},
+		{"employee lists everyone", employee, list(""), codes.PermissionDenied},
+		{"manager lists report", manager, list("e1"), codes.OK},
+		{"manager lists non-report", manager, list("e2"), codes.PermissionDenied},
+		{"manager lists everyone", manager, list(""), codes.PermissionDenied},
+		{"admin lists everyone", admin, list(""), codes.OK},
+		{"employee summary own", employee, summary("e1"), codes.OK},
+		{"employee summary another", employee, summary("e2"), codes.PermissionDenied},
+		{"manager summary report", manager, summary("e1"), codes.OK},
+		{"manager summary non-report", manager, summary("e2"), codes.PermissionDenied},
+		{"employee checks out another", employee, checkOut(r2.GetId()), codes.PermissionDenied},
+		// Indistinguishable from the case above, so ids cannot be probed.
+		{"employee checks out missing", employee, checkOut(missing), codes.PermissionDenied},
+		{"manager checks out report", manager, checkOut(r1.GetId()), codes.PermissionDenied},
+		{"admin checks out missing", admin, checkOut(missing), codes.NotFound},
+		{"employee checks out own", employee, checkOut(r1.GetId()), codes.OK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			wantCode(t, tc.call(tc.ctx), tc.code)
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,11 +24,291 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// --- Request Messages ---
+// Filter on whether a record has been checked out.
+type SessionStatus int32
+
+const (
+	SessionStatus_SESSION_STATUS_UNSPECIFIED SessionStatus = 0 // open and closed
+	SessionStatus_SESSION_STATUS_OPEN        SessionStatus = 1
+	SessionStatus_SESSION_STATUS_CLOSED      SessionStatus = 2
+)
+
+// Enum value maps for SessionStatus.
+var (
+	SessionStatus_name = map[int32]string{
+		0: "SESSION_STATUS_UNSPECIFIED",
+		1: "SESSION_STATUS_OPEN",
+		2: "SESSION_STATUS_CLOSED",
+	}
+	SessionStatus_value = map[string]int32{
+		"SESSION_STATUS_UNSPECIFIED": 0,
+		"SESSION_STATUS_OPEN":        1,
+		"SESSION_STATUS_CLOSED":      2,
+	}
+)
+
+func (x SessionStatus) Enum() *SessionStatus {
+	p := new(SessionStatus)
+	*p = x
+	return p
+}
+
+func (x SessionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_attendance_proto_enumTypes[0].Descriptor()
+}
+
+func (SessionStatus) Type() protoreflect.EnumType {
+	return &file_attendance_proto_enumTypes[0]
+}
+
+func (x SessionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionStatus.Descriptor instead.
+func (SessionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{0}
+}
+
+// Ordering by checkin_time.
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED  SortOrder = 0 // newest first
+	SortOrder_SORT_ORDER_NEWEST_FIRST SortOrder = 1
+	SortOrder_SORT_ORDER_OLDEST_FIRST SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_NEWEST_FIRST",
+		2: "SORT_ORDER_OLDEST_FIRST",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED":  0,
+		"SORT_ORDER_NEWEST_FIRST": 1,
+		"SORT_ORDER_OLDEST_FIRST": 2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_attendance_proto_enumTypes[1].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_attendance_proto_enumTypes[1]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{1}
+}
+
+// A user's attendance on the report day.
+type DayStatus int32
+
+const (
+	DayStatus_DAY_STATUS_UNSPECIFIED DayStatus = 0
+	DayStatus_DAY_STATUS_ABSENT      DayStatus = 1 // no session on the day
+	DayStatus_DAY_STATUS_PRESENT     DayStatus = 2 // came in and has checked out
+	DayStatus_DAY_STATUS_CHECKED_IN  DayStatus = 3 // still checked in
+)
+
+// Enum value maps for DayStatus.
+var (
+	DayStatus_name = map[int32]string{
+		0: "DAY_STATUS_UNSPECIFIED",
+		1: "DAY_STATUS_ABSENT",
+		2: "DAY_STATUS_PRESENT",
+		3: "DAY_STATUS_CHECKED_IN",
+	}
+	DayStatus_value = map[string]int32{
+		"DAY_STATUS_UNSPECIFIED": 0,
+		"DAY_STATUS_ABSENT":      1,
+		"DAY_STATUS_PRESENT":     2,
+		"DAY_STATUS_CHECKED_IN":  3,
+	}
+)
+
+func (x DayStatus) Enum() *DayStatus {
+	p := new(DayStatus)
+	*p = x
+	return p
+}
+
+func (x DayStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DayStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_attendance_proto_enumTypes[2].Descriptor()
+}
+
+func (DayStatus) Type() protoreflect.EnumType {
+	return &file_attendance_proto_enumTypes[2]
+}
+
+func (x DayStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DayStatus.Descriptor instead.
+func (DayStatus) EnumDescriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{2}
+}
+
+// Punctuality of a session against the user's shift. Arriving or leaving
+// within the grace period counts as on time.
+type ShiftStatus int32
+
+const (
+	ShiftStatus_SHIFT_STATUS_UNSPECIFIED         ShiftStatus = 0 // no shift assigned
+	ShiftStatus_SHIFT_STATUS_ON_TIME             ShiftStatus = 1
+	ShiftStatus_SHIFT_STATUS_LATE                ShiftStatus = 2
+	ShiftStatus_SHIFT_STATUS_LEFT_EARLY          ShiftStatus = 3
+	ShiftStatus_SHIFT_STATUS_LATE_AND_LEFT_EARLY ShiftStatus = 4
+	ShiftStatus_SHIFT_STATUS_OFF_SHIFT           ShiftStatus = 5 // checked in outside any scheduled shift
+)
+
+// Enum value maps for ShiftStatus.
+var (
+	ShiftStatus_name = map[int32]string{
+		0: "SHIFT_STATUS_UNSPECIFIED",
+		1: "SHIFT_STATUS_ON_TIME",
+		2: "SHIFT_STATUS_LATE",
+		3: "SHIFT_STATUS_LEFT_EARLY",
+		4: "SHIFT_STATUS_LATE_AND_LEFT_EARLY",
+		5: "SHIFT_STATUS_OFF_SHIFT",
+	}
+	ShiftStatus_value = map[string]int32{
+		"SHIFT_STATUS_UNSPECIFIED":         0,
+		"SHIFT_STATUS_ON_TIME":             1,
+		"SHIFT_STATUS_LATE":                2,
+		"SHIFT_STATUS_LEFT_EARLY":          3,
+		"SHIFT_STATUS_LATE_AND_LEFT_EARLY": 4,
+		"SHIFT_STATUS_OFF_SHIFT":           5,
+	}
+)
+
+func (x ShiftStatus) Enum() *ShiftStatus {
+	p := new(ShiftStatus)
+	*p = x
+	return p
+}
+
+func (x ShiftStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShiftStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_attendance_proto_enumTypes[3].Descriptor()
+}
+
+func (ShiftStatus) Type() protoreflect.EnumType {
+	return &file_attendance_proto_enumTypes[3]
+}
+
+func (x ShiftStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShiftStatus.Descriptor instead.
+func (ShiftStatus) EnumDescriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{3}
+}
+
+type DayOfWeek int32
+
+const (
+	DayOfWeek_DAY_OF_WEEK_UNSPECIFIED DayOfWeek = 0
+	DayOfWeek_DAY_OF_WEEK_MONDAY      DayOfWeek = 1
+	DayOfWeek_DAY_OF_WEEK_TUESDAY     DayOfWeek = 2
+	DayOfWeek_DAY_OF_WEEK_WEDNESDAY   DayOfWeek = 3
+	DayOfWeek_DAY_OF_WEEK_THURSDAY    DayOfWeek = 4
+	DayOfWeek_DAY_OF_WEEK_FRIDAY      DayOfWeek = 5
+	DayOfWeek_DAY_OF_WEEK_SATURDAY    DayOfWeek = 6
+	DayOfWeek_DAY_OF_WEEK_SUNDAY      DayOfWeek = 7
+)
+
+// Enum value maps for DayOfWeek.
+var (
+	DayOfWeek_name = map[int32]string{
+		0: "DAY_OF_WEEK_UNSPECIFIED",
+		1: "DAY_OF_WEEK_MONDAY",
+		2: "DAY_OF_WEEK_TUESDAY",
+		3: "DAY_OF_WEEK_WEDNESDAY",
+		4: "DAY_OF_WEEK_THURSDAY",
+		5: "DAY_OF_WEEK_FRIDAY",
+		6: "DAY_OF_WEEK_SATURDAY",
+		7: "DAY_OF_WEEK_SUNDAY",
+	}
+	DayOfWeek_value = map[string]int32{
+		"DAY_OF_WEEK_UNSPECIFIED": 0,
+		"DAY_OF_WEEK_MONDAY":      1,
+		"DAY_OF_WEEK_TUESDAY":     2,
+		"DAY_OF_WEEK_WEDNESDAY":   3,
+		"DAY_OF_WEEK_THURSDAY":    4,
+		"DAY_OF_WEEK_FRIDAY":      5,
+		"DAY_OF_WEEK_SATURDAY":    6,
+		"DAY_OF_WEEK_SUNDAY":      7,
+	}
+)
+
+func (x DayOfWeek) Enum() *DayOfWeek {
+	p := new(DayOfWeek)
+	*p = x
+	return p
+}
+
+func (x DayOfWeek) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DayOfWeek) Descriptor() protoreflect.EnumDescriptor {
+	return file_attendance_proto_enumTypes[4].Descriptor()
+}
+
+func (DayOfWeek) Type() protoreflect.EnumType {
+	return &file_attendance_proto_enumTypes[4]
+}
+
+func (x DayOfWeek) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DayOfWeek.Descriptor instead.
+func (DayOfWeek) EnumDescriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{4}
+}
+
 type CheckInRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: ignored; the username comes from the user registry.
+	//
+	// Deprecated: Marked as deprecated in attendance.proto.
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TimeZone      string `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -68,6 +350,7 @@ func (x *CheckInRequest) GetUserId() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in attendance.proto.
 func (x *CheckInRequest) GetUsername() string {
 	if x != nil {
 		return x.Username
@@ -75,9 +358,17 @@ func (x *CheckInRequest) GetUsername() string {
 	return ""
 }
 
+func (x *CheckInRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
 type CheckOutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RecordId      string                 `protobuf:"bytes,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -119,27 +410,35 @@ func (x *CheckOutRequest) GetRecordId() string {
 	return ""
 }
 
-type GetAttendanceRequest struct {
+func (x *CheckOutRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type CheckOutUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAttendanceRequest) Reset() {
-	*x = GetAttendanceRequest{}
+func (x *CheckOutUserRequest) Reset() {
+	*x = CheckOutUserRequest{}
 	mi := &file_attendance_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAttendanceRequest) String() string {
+func (x *CheckOutUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAttendanceRequest) ProtoMessage() {}
+func (*CheckOutUserRequest) ProtoMessage() {}
 
-func (x *GetAttendanceRequest) ProtoReflect() protoreflect.Message {
+func (x *CheckOutUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_attendance_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -151,38 +450,47 @@ func (x *GetAttendanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAttendanceRequest.ProtoReflect.Descriptor instead.
-func (*GetAttendanceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckOutUserRequest.ProtoReflect.Descriptor instead.
+func (*CheckOutUserRequest) Descriptor() ([]byte, []int) {
 	return file_attendance_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetAttendanceRequest) GetUserId() string {
+func (x *CheckOutUserRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type GetAllAttendanceRequest struct {
+func (x *CheckOutUserRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type StartBreakRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllAttendanceRequest) Reset() {
-	*x = GetAllAttendanceRequest{}
+func (x *StartBreakRequest) Reset() {
+	*x = StartBreakRequest{}
 	mi := &file_attendance_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllAttendanceRequest) String() string {
+func (x *StartBreakRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllAttendanceRequest) ProtoMessage() {}
+func (*StartBreakRequest) ProtoMessage() {}
 
-func (x *GetAllAttendanceRequest) ProtoReflect() protoreflect.Message {
+func (x *StartBreakRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_attendance_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -194,38 +502,47 @@ func (x *GetAllAttendanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllAttendanceRequest.ProtoReflect.Descriptor instead.
-func (*GetAllAttendanceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartBreakRequest.ProtoReflect.Descriptor instead.
+func (*StartBreakRequest) Descriptor() ([]byte, []int) {
 	return file_attendance_proto_rawDescGZIP(), []int{3}
 }
 
-// --- Response Messages ---
-type AttendanceRecordResponse struct {
+func (x *StartBreakRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StartBreakRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type EndBreakRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
-	CheckinTime   string                 `protobuf:"bytes,4,opt,name=checkin_time,json=checkinTime,proto3" json:"checkin_time,omitempty"`
-	CheckoutTime  string                 `protobuf:"bytes,5,opt,name=checkout_time,json=checkoutTime,proto3" json:"checkout_time,omitempty"`
-	StatusMessage string                 `protobuf:"bytes,6,opt,name=status_message,json=statusMessage,proto3" json:"status_message,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttendanceRecordResponse) Reset() {
-	*x = AttendanceRecordResponse{}
+func (x *EndBreakRequest) Reset() {
+	*x = EndBreakRequest{}
 	mi := &file_attendance_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttendanceRecordResponse) String() string {
+func (x *EndBreakRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttendanceRecordResponse) ProtoMessage() {}
+func (*EndBreakRequest) ProtoMessage() {}
 
-func (x *AttendanceRecordResponse) ProtoReflect() protoreflect.Message {
+func (x *EndBreakRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_attendance_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -237,75 +554,99 @@ func (x *AttendanceRecordResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttendanceRecordResponse.ProtoReflect.Descriptor instead.
-func (*AttendanceRecordResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EndBreakRequest.ProtoReflect.Descriptor instead.
+func (*EndBreakRequest) Descriptor() ([]byte, []int) {
 	return file_attendance_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AttendanceRecordResponse) GetId() string {
+func (x *EndBreakRequest) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *AttendanceRecordResponse) GetUserId() string {
+func (x *EndBreakRequest) GetTimeZone() string {
 	if x != nil {
-		return x.UserId
+		return x.TimeZone
 	}
 	return ""
 }
 
-func (x *AttendanceRecordResponse) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
+type GetAttendanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttendanceRecordResponse) GetCheckinTime() string {
+func (x *GetAttendanceRequest) Reset() {
+	*x = GetAttendanceRequest{}
+	mi := &file_attendance_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttendanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttendanceRequest) ProtoMessage() {}
+
+func (x *GetAttendanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[5]
 	if x != nil {
-		return x.CheckinTime
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *AttendanceRecordResponse) GetCheckoutTime() string {
+// Deprecated: Use GetAttendanceRequest.ProtoReflect.Descriptor instead.
+func (*GetAttendanceRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAttendanceRequest) GetUserId() string {
 	if x != nil {
-		return x.CheckoutTime
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *AttendanceRecordResponse) GetStatusMessage() string {
+func (x *GetAttendanceRequest) GetTimeZone() string {
 	if x != nil {
-		return x.StatusMessage
+		return x.TimeZone
 	}
 	return ""
 }
 
-type GetAllAttendanceResponse struct {
-	state         protoimpl.MessageState      `protogen:"open.v1"`
-	Records       []*AttendanceRecordResponse `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+type GetAllAttendanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TimeZone      string                 `protobuf:"bytes,1,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllAttendanceResponse) Reset() {
-	*x = GetAllAttendanceResponse{}
-	mi := &file_attendance_proto_msgTypes[5]
+func (x *GetAllAttendanceRequest) Reset() {
+	*x = GetAllAttendanceRequest{}
+	mi := &file_attendance_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllAttendanceResponse) String() string {
+func (x *GetAllAttendanceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllAttendanceResponse) ProtoMessage() {}
+func (*GetAllAttendanceRequest) ProtoMessage() {}
 
-func (x *GetAllAttendanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_attendance_proto_msgTypes[5]
+func (x *GetAllAttendanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,46 +657,2453 @@ func (x *GetAllAttendanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllAttendanceResponse.ProtoReflect.Descriptor instead.
-func (*GetAllAttendanceResponse) Descriptor() ([]byte, []int) {
-	return file_attendance_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetAllAttendanceRequest.ProtoReflect.Descriptor instead.
+func (*GetAllAttendanceRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetAllAttendanceResponse) GetRecords() []*AttendanceRecordResponse {
+func (x *GetAllAttendanceRequest) GetTimeZone() string {
 	if x != nil {
-		return x.Records
+		return x.TimeZone
 	}
-	return nil
+	return ""
 }
 
-var File_attendance_proto protoreflect.FileDescriptor
+type ListAttendanceRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PageSize int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // default 50, max 500
+	// next_page_token from the previous page; the filters and sort must be
+	// the same as in that request.
+	PageToken     string        `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	UserId        string        `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate     string        `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // YYYY-MM-DD, inclusive, by checkin_time
+	EndDate       string        `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // YYYY-MM-DD, inclusive, by checkin_time
+	Status        SessionStatus `protobuf:"varint,6,opt,name=status,proto3,enum=attendance.SessionStatus" json:"status,omitempty"`
+	Sort          SortOrder     `protobuf:"varint,7,opt,name=sort,proto3,enum=attendance.SortOrder" json:"sort,omitempty"`
+	TimeZone      string        `protobuf:"bytes,8,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_attendance_proto_rawDesc = "" +
-	"\n" +
-	"\x10attendance.proto\x12\n" +
-	"attendance\x1a\x1cgoogle/api/annotations.proto\"E\n" +
-	"\x0eCheckInRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
-	"\busername\x18\x02 \x01(\tR\busername\".\n" +
-	"\x0fCheckOutRequest\x12\x1b\n" +
-	"\trecord_id\x18\x01 \x01(\tR\brecordId\"/\n" +
+func (x *ListAttendanceRequest) Reset() {
+	*x = ListAttendanceRequest{}
+	mi := &file_attendance_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttendanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttendanceRequest) ProtoMessage() {}
+
+func (x *ListAttendanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttendanceRequest.ProtoReflect.Descriptor instead.
+func (*ListAttendanceRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListAttendanceRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAttendanceRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAttendanceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListAttendanceRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ListAttendanceRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ListAttendanceRequest) GetStatus() SessionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SessionStatus_SESSION_STATUS_UNSPECIFIED
+}
+
+func (x *ListAttendanceRequest) GetSort() SortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListAttendanceRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// Same filters as ListAttendanceRequest, without paging.
+type StreamAttendanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // YYYY-MM-DD, inclusive, by checkin_time
+	EndDate       string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // YYYY-MM-DD, inclusive, by checkin_time
+	Status        SessionStatus          `protobuf:"varint,4,opt,name=status,proto3,enum=attendance.SessionStatus" json:"status,omitempty"`
+	Sort          SortOrder              `protobuf:"varint,5,opt,name=sort,proto3,enum=attendance.SortOrder" json:"sort,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,6,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAttendanceRequest) Reset() {
+	*x = StreamAttendanceRequest{}
+	mi := &file_attendance_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAttendanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAttendanceRequest) ProtoMessage() {}
+
+func (x *StreamAttendanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAttendanceRequest.ProtoReflect.Descriptor instead.
+func (*StreamAttendanceRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamAttendanceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StreamAttendanceRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *StreamAttendanceRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *StreamAttendanceRequest) GetStatus() SessionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SessionStatus_SESSION_STATUS_UNSPECIFIED
+}
+
+func (x *StreamAttendanceRequest) GetSort() SortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *StreamAttendanceRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type GetUserSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // YYYY-MM-DD, inclusive; default Monday of this week
+	EndDate       string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // YYYY-MM-DD, inclusive; default today
+	TimeZone      string                 `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserSummaryRequest) Reset() {
+	*x = GetUserSummaryRequest{}
+	mi := &file_attendance_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSummaryRequest) ProtoMessage() {}
+
+func (x *GetUserSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetUserSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetUserSummaryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserSummaryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetUserSummaryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetUserSummaryRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type GetDailyReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD or "today"
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyReportRequest) Reset() {
+	*x = GetDailyReportRequest{}
+	mi := &file_attendance_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyReportRequest) ProtoMessage() {}
+
+func (x *GetDailyReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyReportRequest.ProtoReflect.Descriptor instead.
+func (*GetDailyReportRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetDailyReportRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GetDailyReportRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// --- Response Messages ---
+type AttendanceRecordResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// Deprecated: display string in the request's time_zone, else the
+	// user's, else the server default; use checkin_at.
+	//
+	// Deprecated: Marked as deprecated in attendance.proto.
+	CheckinTime string `protobuf:"bytes,4,opt,name=checkin_time,json=checkinTime,proto3" json:"checkin_time,omitempty"`
+	// Deprecated: display string in the request's time_zone, else the
+	// user's, else the server default; use checkout_at.
+	//
+	// Deprecated: Marked as deprecated in attendance.proto.
+	CheckoutTime  string                 `protobuf:"bytes,5,opt,name=checkout_time,json=checkoutTime,proto3" json:"checkout_time,omitempty"`
+	StatusMessage string                 `protobuf:"bytes,6,opt,name=status_message,json=statusMessage,proto3" json:"status_message,omitempty"`
+	CheckinAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=checkin_at,json=checkinAt,proto3" json:"checkin_at,omitempty"`
+	CheckoutAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=checkout_at,json=checkoutAt,proto3" json:"checkout_at,omitempty"` // unset while checked in
+	// Time worked: checkout minus checkin for closed records, elapsed so far
+	// for open ones, less break_time.
+	Worked        *durationpb.Duration `protobuf:"bytes,9,opt,name=worked,proto3" json:"worked,omitempty"`
+	WorkedDisplay string               `protobuf:"bytes,10,opt,name=worked_display,json=workedDisplay,proto3" json:"worked_display,omitempty"` // e.g. "7h42m10s"
+	Site          string               `protobuf:"bytes,11,opt,name=site,proto3" json:"site,omitempty"`                                        // site of the kiosk API key used to check in
+	// Punctuality against the user's shift; unset without an assigned shift.
+	ShiftId     string               `protobuf:"bytes,12,opt,name=shift_id,json=shiftId,proto3" json:"shift_id,omitempty"`
+	ShiftStatus ShiftStatus          `protobuf:"varint,13,opt,name=shift_status,json=shiftStatus,proto3,enum=attendance.ShiftStatus" json:"shift_status,omitempty"`
+	LateBy      *durationpb.Duration `protobuf:"bytes,14,opt,name=late_by,json=lateBy,proto3" json:"late_by,omitempty"`                  // checkin after shift start, if late
+	LeftEarlyBy *durationpb.Duration `protobuf:"bytes,15,opt,name=left_early_by,json=leftEarlyBy,proto3" json:"left_early_by,omitempty"` // checkout before shift end, if early
+	// Set when the service checked the user out because they forgot to:
+	// close_reason is "max_session", "shift_ended" or, for a second session
+	// left open by an older version, "duplicate_open_session".
+	AutoClosed    bool                 `protobuf:"varint,16,opt,name=auto_closed,json=autoClosed,proto3" json:"auto_closed,omitempty"`
+	CloseReason   string               `protobuf:"bytes,17,opt,name=close_reason,json=closeReason,proto3" json:"close_reason,omitempty"`
+	Breaks        []*Break             `protobuf:"bytes,18,rep,name=breaks,proto3" json:"breaks,omitempty"`
+	BreakTime     *durationpb.Duration `protobuf:"bytes,19,opt,name=break_time,json=breakTime,proto3" json:"break_time,omitempty"` // total of breaks
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttendanceRecordResponse) Reset() {
+	*x = AttendanceRecordResponse{}
+	mi := &file_attendance_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttendanceRecordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttendanceRecordResponse) ProtoMessage() {}
+
+func (x *AttendanceRecordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttendanceRecordResponse.ProtoReflect.Descriptor instead.
+func (*AttendanceRecordResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AttendanceRecordResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AttendanceRecordResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AttendanceRecordResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in attendance.proto.
+func (x *AttendanceRecordResponse) GetCheckinTime() string {
+	if x != nil {
+		return x.CheckinTime
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in attendance.proto.
+func (x *AttendanceRecordResponse) GetCheckoutTime() string {
+	if x != nil {
+		return x.CheckoutTime
+	}
+	return ""
+}
+
+func (x *AttendanceRecordResponse) GetStatusMessage() string {
+	if x != nil {
+		return x.StatusMessage
+	}
+	return ""
+}
+
+func (x *AttendanceRecordResponse) GetCheckinAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckinAt
+	}
+	return nil
+}
+
+func (x *AttendanceRecordResponse) GetCheckoutAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckoutAt
+	}
+	return nil
+}
+
+func (x *AttendanceRecordResponse) GetWorked() *durationpb.Duration {
+	if x != nil {
+		return x.Worked
+	}
+	return nil
+}
+
+func (x *AttendanceRecordResponse) GetWorkedDisplay() string {
+	if x != nil {
+		return x.WorkedDisplay
+	}
+	return ""
+}
+
+func (x *AttendanceRecordResponse) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *AttendanceRecordResponse) GetShiftId() string {
+	if x != nil {
+		return x.ShiftId
+	}
+	return ""
+}
+
+func (x *AttendanceRecordResponse) GetShiftStatus() ShiftStatus {
+	if x != nil {
+		return x.ShiftStatus
+	}
+	return ShiftStatus_SHIFT_STATUS_UNSPECIFIED
+}
+
+func (x *AttendanceRecordResponse) GetLateBy() *durationpb.Duration {
+	if x != nil {
+		return x.LateBy
+	}
+	return nil
+}
+
+func (x *AttendanceRecordResponse) GetLeftEarlyBy() *durationpb.Duration {
+	if x != nil {
+		return x.LeftEarlyBy
+	}
+	return nil
+}
+
+func (x *AttendanceRecordResponse) GetAutoClosed() bool {
+	if x != nil {
+		return x.AutoClosed
+	}
+	return false
+}
+
+func (x *AttendanceRecordResponse) GetCloseReason() string {
+	if x != nil {
+		return x.CloseReason
+	}
+	return ""
+}
+
+func (x *AttendanceRecordResponse) GetBreaks() []*Break {
+	if x != nil {
+		return x.Breaks
+	}
+	return nil
+}
+
+func (x *AttendanceRecordResponse) GetBreakTime() *durationpb.Duration {
+	if x != nil {
+		return x.BreakTime
+	}
+	return nil
+}
+
+// A break within a session; it does not count as worked time.
+type Break struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartAt       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	EndAt         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"` // unset while on the break
+	Duration      *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`        // so far, while on the break
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Break) Reset() {
+	*x = Break{}
+	mi := &file_attendance_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Break) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Break) ProtoMessage() {}
+
+func (x *Break) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Break.ProtoReflect.Descriptor instead.
+func (*Break) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Break) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *Break) GetEndAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+func (x *Break) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+type GetAllAttendanceResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Records       []*AttendanceRecordResponse `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllAttendanceResponse) Reset() {
+	*x = GetAllAttendanceResponse{}
+	mi := &file_attendance_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllAttendanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllAttendanceResponse) ProtoMessage() {}
+
+func (x *GetAllAttendanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllAttendanceResponse.ProtoReflect.Descriptor instead.
+func (*GetAllAttendanceResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetAllAttendanceResponse) GetRecords() []*AttendanceRecordResponse {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type ListAttendanceResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Records       []*AttendanceRecordResponse `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	NextPageToken string                      `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	TotalCount    int64                       `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`           // records matching the filters, across all pages
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttendanceResponse) Reset() {
+	*x = ListAttendanceResponse{}
+	mi := &file_attendance_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttendanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttendanceResponse) ProtoMessage() {}
+
+func (x *ListAttendanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttendanceResponse.ProtoReflect.Descriptor instead.
+func (*ListAttendanceResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListAttendanceResponse) GetRecords() []*AttendanceRecordResponse {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListAttendanceResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListAttendanceResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// Totals for one calendar day. Sessions that cross midnight are split and
+// counted on each day they touch.
+type DaySummary struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Date           string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD
+	FirstIn        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=first_in,json=firstIn,proto3" json:"first_in,omitempty"`
+	LastOut        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_out,json=lastOut,proto3" json:"last_out,omitempty"` // unset while still checked in
+	Sessions       int32                  `protobuf:"varint,4,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	Worked         *durationpb.Duration   `protobuf:"bytes,5,opt,name=worked,proto3" json:"worked,omitempty"`
+	WorkedDisplay  string                 `protobuf:"bytes,6,opt,name=worked_display,json=workedDisplay,proto3" json:"worked_display,omitempty"`
+	StillCheckedIn bool                   `protobuf:"varint,7,opt,name=still_checked_in,json=stillCheckedIn,proto3" json:"still_checked_in,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DaySummary) Reset() {
+	*x = DaySummary{}
+	mi := &file_attendance_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaySummary) ProtoMessage() {}
+
+func (x *DaySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaySummary.ProtoReflect.Descriptor instead.
+func (*DaySummary) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DaySummary) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DaySummary) GetFirstIn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstIn
+	}
+	return nil
+}
+
+func (x *DaySummary) GetLastOut() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastOut
+	}
+	return nil
+}
+
+func (x *DaySummary) GetSessions() int32 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *DaySummary) GetWorked() *durationpb.Duration {
+	if x != nil {
+		return x.Worked
+	}
+	return nil
+}
+
+func (x *DaySummary) GetWorkedDisplay() string {
+	if x != nil {
+		return x.WorkedDisplay
+	}
+	return ""
+}
+
+func (x *DaySummary) GetStillCheckedIn() bool {
+	if x != nil {
+		return x.StillCheckedIn
+	}
+	return false
+}
+
+type GetUserSummaryResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	UserId             string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Days               []*DaySummary          `protobuf:"bytes,2,rep,name=days,proto3" json:"days,omitempty"` // one entry per day in the range
+	TotalWorked        *durationpb.Duration   `protobuf:"bytes,3,opt,name=total_worked,json=totalWorked,proto3" json:"total_worked,omitempty"`
+	TotalWorkedDisplay string                 `protobuf:"bytes,4,opt,name=total_worked_display,json=totalWorkedDisplay,proto3" json:"total_worked_display,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetUserSummaryResponse) Reset() {
+	*x = GetUserSummaryResponse{}
+	mi := &file_attendance_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSummaryResponse) ProtoMessage() {}
+
+func (x *GetUserSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetUserSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetUserSummaryResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserSummaryResponse) GetDays() []*DaySummary {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GetUserSummaryResponse) GetTotalWorked() *durationpb.Duration {
+	if x != nil {
+		return x.TotalWorked
+	}
+	return nil
+}
+
+func (x *GetUserSummaryResponse) GetTotalWorkedDisplay() string {
+	if x != nil {
+		return x.TotalWorkedDisplay
+	}
+	return ""
+}
+
+type UserDayReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Status        DayStatus              `protobuf:"varint,3,opt,name=status,proto3,enum=attendance.DayStatus" json:"status,omitempty"`
+	FirstIn       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=first_in,json=firstIn,proto3" json:"first_in,omitempty"`
+	LastOut       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_out,json=lastOut,proto3" json:"last_out,omitempty"` // unset unless PRESENT
+	Sessions      int32                  `protobuf:"varint,6,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	Worked        *durationpb.Duration   `protobuf:"bytes,7,opt,name=worked,proto3" json:"worked,omitempty"`
+	WorkedDisplay string                 `protobuf:"bytes,8,opt,name=worked_display,json=workedDisplay,proto3" json:"worked_display,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserDayReport) Reset() {
+	*x = UserDayReport{}
+	mi := &file_attendance_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDayReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDayReport) ProtoMessage() {}
+
+func (x *UserDayReport) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDayReport.ProtoReflect.Descriptor instead.
+func (*UserDayReport) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UserDayReport) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserDayReport) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserDayReport) GetStatus() DayStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DayStatus_DAY_STATUS_UNSPECIFIED
+}
+
+func (x *UserDayReport) GetFirstIn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstIn
+	}
+	return nil
+}
+
+func (x *UserDayReport) GetLastOut() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastOut
+	}
+	return nil
+}
+
+func (x *UserDayReport) GetSessions() int32 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *UserDayReport) GetWorked() *durationpb.Duration {
+	if x != nil {
+		return x.Worked
+	}
+	return nil
+}
+
+func (x *UserDayReport) GetWorkedDisplay() string {
+	if x != nil {
+		return x.WorkedDisplay
+	}
+	return ""
+}
+
+type GetDailyReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Date  string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// Active users, plus deactivated ones who worked that day, by user_id.
+	Users          []*UserDayReport `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	PresentCount   int32            `protobuf:"varint,3,opt,name=present_count,json=presentCount,proto3" json:"present_count,omitempty"`
+	CheckedInCount int32            `protobuf:"varint,4,opt,name=checked_in_count,json=checkedInCount,proto3" json:"checked_in_count,omitempty"`
+	AbsentCount    int32            `protobuf:"varint,5,opt,name=absent_count,json=absentCount,proto3" json:"absent_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDailyReportResponse) Reset() {
+	*x = GetDailyReportResponse{}
+	mi := &file_attendance_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyReportResponse) ProtoMessage() {}
+
+func (x *GetDailyReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyReportResponse.ProtoReflect.Descriptor instead.
+func (*GetDailyReportResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetDailyReportResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GetDailyReportResponse) GetUsers() []*UserDayReport {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *GetDailyReportResponse) GetPresentCount() int32 {
+	if x != nil {
+		return x.PresentCount
+	}
+	return 0
+}
+
+func (x *GetDailyReportResponse) GetCheckedInCount() int32 {
+	if x != nil {
+		return x.CheckedInCount
+	}
+	return 0
+}
+
+func (x *GetDailyReportResponse) GetAbsentCount() int32 {
+	if x != nil {
+		return x.AbsentCount
+	}
+	return 0
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"` // IANA name; empty means the server default
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ManagerId     string                 `protobuf:"bytes,7,opt,name=manager_id,json=managerId,proto3" json:"manager_id,omitempty"` // user_id of the user's manager, if any
+	ShiftId       string                 `protobuf:"bytes,8,opt,name=shift_id,json=shiftId,proto3" json:"shift_id,omitempty"`       // assigned shift, if any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_attendance_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *User) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *User) GetManagerId() string {
+	if x != nil {
+		return x.ManagerId
+	}
+	return ""
+}
+
+func (x *User) GetShiftId() string {
+	if x != nil {
+		return x.ShiftId
+	}
+	return ""
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	ManagerId     string                 `protobuf:"bytes,4,opt,name=manager_id,json=managerId,proto3" json:"manager_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_attendance_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetManagerId() string {
+	if x != nil {
+		return x.ManagerId
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_attendance_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Empty fields are left unchanged.
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	ManagerId     string                 `protobuf:"bytes,4,opt,name=manager_id,json=managerId,proto3" json:"manager_id,omitempty"` // must be a registered user
+	Active        *bool                  `protobuf:"varint,5,opt,name=active,proto3,oneof" json:"active,omitempty"`                 // true reactivates, false deactivates
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_attendance_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetManagerId() string {
+	if x != nil {
+		return x.ManagerId
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetActive() bool {
+	if x != nil && x.Active != nil {
+		return *x.Active
+	}
+	return false
+}
+
+type DeactivateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateUserRequest) Reset() {
+	*x = DeactivateUserRequest{}
+	mi := &file_attendance_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateUserRequest) ProtoMessage() {}
+
+func (x *DeactivateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateUserRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateUserRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeactivateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PageSize        int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // default 50, max 500
+	PageToken       string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token from the previous page
+	IncludeInactive bool                   `protobuf:"varint,3,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_attendance_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // ordered by user_id
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_attendance_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// An API key for a kiosk or badge reader. It is bound to one site and may
+// only call the listed check-in/check-out methods.
+type ApiKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Site          string                 `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
+	Methods       []string               `protobuf:"bytes,4,rep,name=methods,proto3" json:"methods,omitempty"` // subset of CheckIn, CheckOut, CheckOutUser
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"` // unset while active
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiKey) Reset() {
+	*x = ApiKey{}
+	mi := &file_attendance_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKey) ProtoMessage() {}
+
+func (x *ApiKey) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKey.ProtoReflect.Descriptor instead.
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ApiKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApiKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiKey) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *ApiKey) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *ApiKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ApiKey) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+type CreateApiKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Site          string                 `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	Methods       []string               `protobuf:"bytes,3,rep,name=methods,proto3" json:"methods,omitempty"` // default: all of CheckIn, CheckOut, CheckOutUser
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyRequest) Reset() {
+	*x = CreateApiKeyRequest{}
+	mi := &file_attendance_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyRequest) ProtoMessage() {}
+
+func (x *CreateApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateApiKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiKeyRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *CreateApiKeyRequest) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+type CreateApiKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *ApiKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"` // send as x-api-key; only returned here, never stored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyResponse) Reset() {
+	*x = CreateApiKeyResponse{}
+	mi := &file_attendance_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyResponse) ProtoMessage() {}
+
+func (x *CreateApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateApiKeyResponse) GetApiKey() *ApiKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateApiKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type ListApiKeysRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IncludeRevoked bool                   `protobuf:"varint,1,opt,name=include_revoked,json=includeRevoked,proto3" json:"include_revoked,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListApiKeysRequest) Reset() {
+	*x = ListApiKeysRequest{}
+	mi := &file_attendance_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysRequest) ProtoMessage() {}
+
+func (x *ListApiKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListApiKeysRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListApiKeysRequest) GetIncludeRevoked() bool {
+	if x != nil {
+		return x.IncludeRevoked
+	}
+	return false
+}
+
+type ListApiKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*ApiKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysResponse) Reset() {
+	*x = ListApiKeysResponse{}
+	mi := &file_attendance_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysResponse) ProtoMessage() {}
+
+func (x *ListApiKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListApiKeysResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListApiKeysResponse) GetApiKeys() []*ApiKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+type RevokeApiKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiKeyRequest) Reset() {
+	*x = RevokeApiKeyRequest{}
+	mi := &file_attendance_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyRequest) ProtoMessage() {}
+
+func (x *RevokeApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RevokeApiKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Shift struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShiftId       string                 `protobuf:"bytes,1,opt,name=shift_id,json=shiftId,proto3" json:"shift_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	StartTime     string                 `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // HH:MM in time_zone
+	EndTime       string                 `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // HH:MM; at or before start_time means the next day
+	GracePeriod   *durationpb.Duration   `protobuf:"bytes,5,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
+	DaysOfWeek    []DayOfWeek            `protobuf:"varint,6,rep,packed,name=days_of_week,json=daysOfWeek,proto3,enum=attendance.DayOfWeek" json:"days_of_week,omitempty"` // days the shift starts on; empty means every day
+	TimeZone      string                 `protobuf:"bytes,7,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                                           // IANA name; empty means the server default
+	Overnight     bool                   `protobuf:"varint,8,opt,name=overnight,proto3" json:"overnight,omitempty"`                                                        // output only: the shift ends the next day
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Shift) Reset() {
+	*x = Shift{}
+	mi := &file_attendance_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shift) ProtoMessage() {}
+
+func (x *Shift) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shift.ProtoReflect.Descriptor instead.
+func (*Shift) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Shift) GetShiftId() string {
+	if x != nil {
+		return x.ShiftId
+	}
+	return ""
+}
+
+func (x *Shift) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Shift) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *Shift) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *Shift) GetGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GracePeriod
+	}
+	return nil
+}
+
+func (x *Shift) GetDaysOfWeek() []DayOfWeek {
+	if x != nil {
+		return x.DaysOfWeek
+	}
+	return nil
+}
+
+func (x *Shift) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Shift) GetOvernight() bool {
+	if x != nil {
+		return x.Overnight
+	}
+	return false
+}
+
+func (x *Shift) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Shift) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetShiftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShiftId       string                 `protobuf:"bytes,1,opt,name=shift_id,json=shiftId,proto3" json:"shift_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShiftRequest) Reset() {
+	*x = GetShiftRequest{}
+	mi := &file_attendance_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShiftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShiftRequest) ProtoMessage() {}
+
+func (x *GetShiftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShiftRequest.ProtoReflect.Descriptor instead.
+func (*GetShiftRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetShiftRequest) GetShiftId() string {
+	if x != nil {
+		return x.ShiftId
+	}
+	return ""
+}
+
+type DeleteShiftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShiftId       string                 `protobuf:"bytes,1,opt,name=shift_id,json=shiftId,proto3" json:"shift_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShiftRequest) Reset() {
+	*x = DeleteShiftRequest{}
+	mi := &file_attendance_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShiftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShiftRequest) ProtoMessage() {}
+
+func (x *DeleteShiftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShiftRequest.ProtoReflect.Descriptor instead.
+func (*DeleteShiftRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteShiftRequest) GetShiftId() string {
+	if x != nil {
+		return x.ShiftId
+	}
+	return ""
+}
+
+type DeleteShiftResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShiftResponse) Reset() {
+	*x = DeleteShiftResponse{}
+	mi := &file_attendance_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShiftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShiftResponse) ProtoMessage() {}
+
+func (x *DeleteShiftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShiftResponse.ProtoReflect.Descriptor instead.
+func (*DeleteShiftResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{35}
+}
+
+type ListShiftsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShiftsRequest) Reset() {
+	*x = ListShiftsRequest{}
+	mi := &file_attendance_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShiftsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShiftsRequest) ProtoMessage() {}
+
+func (x *ListShiftsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShiftsRequest.ProtoReflect.Descriptor instead.
+func (*ListShiftsRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{36}
+}
+
+type ListShiftsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shifts        []*Shift               `protobuf:"bytes,1,rep,name=shifts,proto3" json:"shifts,omitempty"` // ordered by shift_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShiftsResponse) Reset() {
+	*x = ListShiftsResponse{}
+	mi := &file_attendance_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShiftsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShiftsResponse) ProtoMessage() {}
+
+func (x *ListShiftsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShiftsResponse.ProtoReflect.Descriptor instead.
+func (*ListShiftsResponse) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListShiftsResponse) GetShifts() []*Shift {
+	if x != nil {
+		return x.Shifts
+	}
+	return nil
+}
+
+type AssignShiftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShiftId       string                 `protobuf:"bytes,2,opt,name=shift_id,json=shiftId,proto3" json:"shift_id,omitempty"` // empty to unassign
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignShiftRequest) Reset() {
+	*x = AssignShiftRequest{}
+	mi := &file_attendance_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignShiftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignShiftRequest) ProtoMessage() {}
+
+func (x *AssignShiftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_attendance_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignShiftRequest.ProtoReflect.Descriptor instead.
+func (*AssignShiftRequest) Descriptor() ([]byte, []int) {
+	return file_attendance_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AssignShiftRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AssignShiftRequest) GetShiftId() string {
+	if x != nil {
+		return x.ShiftId
+	}
+	return ""
+}
+
+var File_attendance_proto protoreflect.FileDescriptor
+
+const file_attendance_proto_rawDesc = "" +
+	"\n" +
+	"\x10attendance.proto\x12\n" +
+	"attendance\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"f\n" +
+	"\x0eCheckInRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\busername\x18\x02 \x01(\tB\x02\x18\x01R\busername\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\"K\n" +
+	"\x0fCheckOutRequest\x12\x1b\n" +
+	"\trecord_id\x18\x01 \x01(\tR\brecordId\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"K\n" +
+	"\x13CheckOutUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"I\n" +
+	"\x11StartBreakRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"G\n" +
+	"\x0fEndBreakRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"L\n" +
 	"\x14GetAttendanceRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x19\n" +
-	"\x17GetAllAttendanceRequest\"\xce\x01\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"6\n" +
+	"\x17GetAllAttendanceRequest\x12\x1b\n" +
+	"\ttime_zone\x18\x01 \x01(\tR\btimeZone\"\xa1\x02\n" +
+	"\x15ListAttendanceRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x05 \x01(\tR\aendDate\x121\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x19.attendance.SessionStatusR\x06status\x12)\n" +
+	"\x04sort\x18\a \x01(\x0e2\x15.attendance.SortOrderR\x04sort\x12\x1b\n" +
+	"\ttime_zone\x18\b \x01(\tR\btimeZone\"\xe7\x01\n" +
+	"\x17StreamAttendanceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x121\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x19.attendance.SessionStatusR\x06status\x12)\n" +
+	"\x04sort\x18\x05 \x01(\x0e2\x15.attendance.SortOrderR\x04sort\x12\x1b\n" +
+	"\ttime_zone\x18\x06 \x01(\tR\btimeZone\"\x87\x01\n" +
+	"\x15GetUserSummaryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\"H\n" +
+	"\x15GetDailyReportRequest\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"\xaf\x06\n" +
 	"\x18AttendanceRecordResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
-	"\busername\x18\x03 \x01(\tR\busername\x12!\n" +
-	"\fcheckin_time\x18\x04 \x01(\tR\vcheckinTime\x12#\n" +
-	"\rcheckout_time\x18\x05 \x01(\tR\fcheckoutTime\x12%\n" +
-	"\x0estatus_message\x18\x06 \x01(\tR\rstatusMessage\"Z\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12%\n" +
+	"\fcheckin_time\x18\x04 \x01(\tB\x02\x18\x01R\vcheckinTime\x12'\n" +
+	"\rcheckout_time\x18\x05 \x01(\tB\x02\x18\x01R\fcheckoutTime\x12%\n" +
+	"\x0estatus_message\x18\x06 \x01(\tR\rstatusMessage\x129\n" +
+	"\n" +
+	"checkin_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcheckinAt\x12;\n" +
+	"\vcheckout_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"checkoutAt\x121\n" +
+	"\x06worked\x18\t \x01(\v2\x19.google.protobuf.DurationR\x06worked\x12%\n" +
+	"\x0eworked_display\x18\n" +
+	" \x01(\tR\rworkedDisplay\x12\x12\n" +
+	"\x04site\x18\v \x01(\tR\x04site\x12\x19\n" +
+	"\bshift_id\x18\f \x01(\tR\ashiftId\x12:\n" +
+	"\fshift_status\x18\r \x01(\x0e2\x17.attendance.ShiftStatusR\vshiftStatus\x122\n" +
+	"\alate_by\x18\x0e \x01(\v2\x19.google.protobuf.DurationR\x06lateBy\x12=\n" +
+	"\rleft_early_by\x18\x0f \x01(\v2\x19.google.protobuf.DurationR\vleftEarlyBy\x12\x1f\n" +
+	"\vauto_closed\x18\x10 \x01(\bR\n" +
+	"autoClosed\x12!\n" +
+	"\fclose_reason\x18\x11 \x01(\tR\vcloseReason\x12)\n" +
+	"\x06breaks\x18\x12 \x03(\v2\x11.attendance.BreakR\x06breaks\x128\n" +
+	"\n" +
+	"break_time\x18\x13 \x01(\v2\x19.google.protobuf.DurationR\tbreakTime\"\xa8\x01\n" +
+	"\x05Break\x125\n" +
+	"\bstart_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
+	"\x06end_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\"Z\n" +
 	"\x18GetAllAttendanceResponse\x12>\n" +
-	"\arecords\x18\x01 \x03(\v2$.attendance.AttendanceRecordResponseR\arecords2\xde\x03\n" +
+	"\arecords\x18\x01 \x03(\v2$.attendance.AttendanceRecordResponseR\arecords\"\xa1\x01\n" +
+	"\x16ListAttendanceResponse\x12>\n" +
+	"\arecords\x18\x01 \x03(\v2$.attendance.AttendanceRecordResponseR\arecords\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x03R\n" +
+	"totalCount\"\xae\x02\n" +
+	"\n" +
+	"DaySummary\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x125\n" +
+	"\bfirst_in\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\afirstIn\x125\n" +
+	"\blast_out\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\alastOut\x12\x1a\n" +
+	"\bsessions\x18\x04 \x01(\x05R\bsessions\x121\n" +
+	"\x06worked\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06worked\x12%\n" +
+	"\x0eworked_display\x18\x06 \x01(\tR\rworkedDisplay\x12(\n" +
+	"\x10still_checked_in\x18\a \x01(\bR\x0estillCheckedIn\"\xcd\x01\n" +
+	"\x16GetUserSummaryResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12*\n" +
+	"\x04days\x18\x02 \x03(\v2\x16.attendance.DaySummaryR\x04days\x12<\n" +
+	"\ftotal_worked\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vtotalWorked\x120\n" +
+	"\x14total_worked_display\x18\x04 \x01(\tR\x12totalWorkedDisplay\"\xd7\x02\n" +
+	"\rUserDayReport\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12-\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x15.attendance.DayStatusR\x06status\x125\n" +
+	"\bfirst_in\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\afirstIn\x125\n" +
+	"\blast_out\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\alastOut\x12\x1a\n" +
+	"\bsessions\x18\x06 \x01(\x05R\bsessions\x121\n" +
+	"\x06worked\x18\a \x01(\v2\x19.google.protobuf.DurationR\x06worked\x12%\n" +
+	"\x0eworked_display\x18\b \x01(\tR\rworkedDisplay\"\xcf\x01\n" +
+	"\x16GetDailyReportResponse\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12/\n" +
+	"\x05users\x18\x02 \x03(\v2\x19.attendance.UserDayReportR\x05users\x12#\n" +
+	"\rpresent_count\x18\x03 \x01(\x05R\fpresentCount\x12(\n" +
+	"\x10checked_in_count\x18\x04 \x01(\x05R\x0echeckedInCount\x12!\n" +
+	"\fabsent_count\x18\x05 \x01(\x05R\vabsentCount\"\xa0\x02\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
+	"\x06active\x18\x03 \x01(\bR\x06active\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"manager_id\x18\a \x01(\tR\tmanagerId\x12\x19\n" +
+	"\bshift_id\x18\b \x01(\tR\ashiftId\"\x84\x01\n" +
+	"\x11CreateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12\x1d\n" +
+	"\n" +
+	"manager_id\x18\x04 \x01(\tR\tmanagerId\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xac\x01\n" +
+	"\x11UpdateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12\x1d\n" +
+	"\n" +
+	"manager_id\x18\x04 \x01(\tR\tmanagerId\x12\x1b\n" +
+	"\x06active\x18\x05 \x01(\bH\x00R\x06active\x88\x01\x01B\t\n" +
+	"\a_active\"0\n" +
+	"\x15DeactivateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"y\n" +
+	"\x10ListUsersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12)\n" +
+	"\x10include_inactive\x18\x03 \x01(\bR\x0fincludeInactive\"c\n" +
+	"\x11ListUsersResponse\x12&\n" +
+	"\x05users\x18\x01 \x03(\v2\x10.attendance.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd0\x01\n" +
+	"\x06ApiKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04site\x18\x03 \x01(\tR\x04site\x12\x18\n" +
+	"\amethods\x18\x04 \x03(\tR\amethods\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"W\n" +
+	"\x13CreateApiKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x12\x18\n" +
+	"\amethods\x18\x03 \x03(\tR\amethods\"U\n" +
+	"\x14CreateApiKeyResponse\x12+\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x12.attendance.ApiKeyR\x06apiKey\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"=\n" +
+	"\x12ListApiKeysRequest\x12'\n" +
+	"\x0finclude_revoked\x18\x01 \x01(\bR\x0eincludeRevoked\"D\n" +
+	"\x13ListApiKeysResponse\x12-\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x12.attendance.ApiKeyR\aapiKeys\"%\n" +
+	"\x13RevokeApiKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x98\x03\n" +
+	"\x05Shift\x12\x19\n" +
+	"\bshift_id\x18\x01 \x01(\tR\ashiftId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\tR\aendTime\x12<\n" +
+	"\fgrace_period\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\vgracePeriod\x127\n" +
+	"\fdays_of_week\x18\x06 \x03(\x0e2\x15.attendance.DayOfWeekR\n" +
+	"daysOfWeek\x12\x1b\n" +
+	"\ttime_zone\x18\a \x01(\tR\btimeZone\x12\x1c\n" +
+	"\tovernight\x18\b \x01(\bR\tovernight\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\",\n" +
+	"\x0fGetShiftRequest\x12\x19\n" +
+	"\bshift_id\x18\x01 \x01(\tR\ashiftId\"/\n" +
+	"\x12DeleteShiftRequest\x12\x19\n" +
+	"\bshift_id\x18\x01 \x01(\tR\ashiftId\"\x15\n" +
+	"\x13DeleteShiftResponse\"\x13\n" +
+	"\x11ListShiftsRequest\"?\n" +
+	"\x12ListShiftsResponse\x12)\n" +
+	"\x06shifts\x18\x01 \x03(\v2\x11.attendance.ShiftR\x06shifts\"H\n" +
+	"\x12AssignShiftRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bshift_id\x18\x02 \x01(\tR\ashiftId*c\n" +
+	"\rSessionStatus\x12\x1e\n" +
+	"\x1aSESSION_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SESSION_STATUS_OPEN\x10\x01\x12\x19\n" +
+	"\x15SESSION_STATUS_CLOSED\x10\x02*a\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SORT_ORDER_NEWEST_FIRST\x10\x01\x12\x1b\n" +
+	"\x17SORT_ORDER_OLDEST_FIRST\x10\x02*q\n" +
+	"\tDayStatus\x12\x1a\n" +
+	"\x16DAY_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11DAY_STATUS_ABSENT\x10\x01\x12\x16\n" +
+	"\x12DAY_STATUS_PRESENT\x10\x02\x12\x19\n" +
+	"\x15DAY_STATUS_CHECKED_IN\x10\x03*\xbb\x01\n" +
+	"\vShiftStatus\x12\x1c\n" +
+	"\x18SHIFT_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SHIFT_STATUS_ON_TIME\x10\x01\x12\x15\n" +
+	"\x11SHIFT_STATUS_LATE\x10\x02\x12\x1b\n" +
+	"\x17SHIFT_STATUS_LEFT_EARLY\x10\x03\x12$\n" +
+	" SHIFT_STATUS_LATE_AND_LEFT_EARLY\x10\x04\x12\x1a\n" +
+	"\x16SHIFT_STATUS_OFF_SHIFT\x10\x05*\xd8\x01\n" +
+	"\tDayOfWeek\x12\x1b\n" +
+	"\x17DAY_OF_WEEK_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12DAY_OF_WEEK_MONDAY\x10\x01\x12\x17\n" +
+	"\x13DAY_OF_WEEK_TUESDAY\x10\x02\x12\x19\n" +
+	"\x15DAY_OF_WEEK_WEDNESDAY\x10\x03\x12\x18\n" +
+	"\x14DAY_OF_WEEK_THURSDAY\x10\x04\x12\x16\n" +
+	"\x12DAY_OF_WEEK_FRIDAY\x10\x05\x12\x18\n" +
+	"\x14DAY_OF_WEEK_SATURDAY\x10\x06\x12\x16\n" +
+	"\x12DAY_OF_WEEK_SUNDAY\x10\a2\xad\n" +
+	"\n" +
 	"\x11AttendanceService\x12c\n" +
 	"\aCheckIn\x12\x1a.attendance.CheckInRequest\x1a$.attendance.AttendanceRecordResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/checkin\x12r\n" +
-	"\bCheckOut\x12\x1b.attendance.CheckOutRequest\x1a$.attendance.AttendanceRecordResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\x1a\x18/v1/checkout/{record_id}\x12y\n" +
-	"\rGetAttendance\x12 .attendance.GetAttendanceRequest\x1a$.attendance.AttendanceRecordResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/attendance/{user_id}\x12u\n" +
-	"\x10GetAllAttendance\x12#.attendance.GetAllAttendanceRequest\x1a$.attendance.GetAllAttendanceResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/attendanceB\x19Z\x17attendance1/proto;protob\x06proto3"
+	"\bCheckOut\x12\x1b.attendance.CheckOutRequest\x1a$.attendance.AttendanceRecordResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\x1a\x18/v1/checkout/{record_id}\x12~\n" +
+	"\fCheckOutUser\x12\x1f.attendance.CheckOutUserRequest\x1a$.attendance.AttendanceRecordResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/users/{user_id}/checkout\x12}\n" +
+	"\n" +
+	"StartBreak\x12\x1d.attendance.StartBreakRequest\x1a$.attendance.AttendanceRecordResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/users/{user_id}/break:start\x12w\n" +
+	"\bEndBreak\x12\x1b.attendance.EndBreakRequest\x1a$.attendance.AttendanceRecordResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/users/{user_id}/break:end\x12y\n" +
+	"\rGetAttendance\x12 .attendance.GetAttendanceRequest\x1a$.attendance.AttendanceRecordResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/attendance/{user_id}\x12b\n" +
+	"\x10GetAllAttendance\x12#.attendance.GetAllAttendanceRequest\x1a$.attendance.GetAllAttendanceResponse\"\x03\x88\x02\x01\x12o\n" +
+	"\x0eListAttendance\x12!.attendance.ListAttendanceRequest\x1a\".attendance.ListAttendanceResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/attendance\x12|\n" +
+	"\x0eGetUserSummary\x12!.attendance.GetUserSummaryRequest\x1a\".attendance.GetUserSummaryResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/users/{user_id}/summary\x12y\n" +
+	"\x0eGetDailyReport\x12!.attendance.GetDailyReportRequest\x1a\".attendance.GetDailyReportResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/reports/daily/{date}\x12~\n" +
+	"\x10StreamAttendance\x12#.attendance.StreamAttendanceRequest\x1a$.attendance.AttendanceRecordResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/attendance:stream0\x012\xe6\x03\n" +
+	"\vUserService\x12S\n" +
+	"\n" +
+	"CreateUser\x12\x1d.attendance.CreateUserRequest\x1a\x10.attendance.User\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12T\n" +
+	"\aGetUser\x12\x1a.attendance.GetUserRequest\x1a\x10.attendance.User\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/users/{user_id}\x12]\n" +
+	"\n" +
+	"UpdateUser\x12\x1d.attendance.UpdateUserRequest\x1a\x10.attendance.User\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*2\x13/v1/users/{user_id}\x12p\n" +
+	"\x0eDeactivateUser\x12!.attendance.DeactivateUserRequest\x1a\x10.attendance.User\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/users/{user_id}:deactivate\x12[\n" +
+	"\tListUsers\x12\x1c.attendance.ListUsersRequest\x1a\x1d.attendance.ListUsersResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/users2\xc8\x02\n" +
+	"\rApiKeyService\x12i\n" +
+	"\fCreateApiKey\x12\x1f.attendance.CreateApiKeyRequest\x1a .attendance.CreateApiKeyResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/apikeys\x12c\n" +
+	"\vListApiKeys\x12\x1e.attendance.ListApiKeysRequest\x1a\x1f.attendance.ListApiKeysResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/apikeys\x12g\n" +
+	"\fRevokeApiKey\x12\x1f.attendance.RevokeApiKeyRequest\x1a\x12.attendance.ApiKey\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/apikeys/{id}:revoke2\xc3\x04\n" +
+	"\fShiftService\x12J\n" +
+	"\vCreateShift\x12\x11.attendance.Shift\x1a\x11.attendance.Shift\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/shifts\x12Y\n" +
+	"\bGetShift\x12\x1b.attendance.GetShiftRequest\x1a\x11.attendance.Shift\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/shifts/{shift_id}\x12U\n" +
+	"\vUpdateShift\x12\x11.attendance.Shift\x1a\x11.attendance.Shift\" \x82\xd3\xe4\x93\x02\x1a:\x01*\x1a\x15/v1/shifts/{shift_id}\x12m\n" +
+	"\vDeleteShift\x12\x1e.attendance.DeleteShiftRequest\x1a\x1f.attendance.DeleteShiftResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/v1/shifts/{shift_id}\x12_\n" +
+	"\n" +
+	"ListShifts\x12\x1d.attendance.ListShiftsRequest\x1a\x1e.attendance.ListShiftsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/shifts\x12e\n" +
+	"\vAssignShift\x12\x1e.attendance.AssignShiftRequest\x1a\x10.attendance.User\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\x1a\x19/v1/users/{user_id}/shiftB\x19Z\x17attendance1/proto;protob\x06proto3"
 
 var (
 	file_attendance_proto_rawDescOnce sync.Once
@@ -369,30 +3117,151 @@ func file_attendance_proto_rawDescGZIP() []byte {
 	return file_attendance_proto_rawDescData
 }
 
-var file_attendance_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_attendance_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_attendance_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_attendance_proto_goTypes = []any{
-	(*CheckInRequest)(nil),           // 0: attendance.CheckInRequest
-	(*CheckOutRequest)(nil),          // 1: attendance.CheckOutRequest
-	(*GetAttendanceRequest)(nil),     // 2: attendance.GetAttendanceRequest
-	(*GetAllAttendanceRequest)(nil),  // 3: attendance.GetAllAttendanceRequest
-	(*AttendanceRecordResponse)(nil), // 4: attendance.AttendanceRecordResponse
-	(*GetAllAttendanceResponse)(nil), // 5: attendance.GetAllAttendanceResponse
+	(SessionStatus)(0),               // 0: attendance.SessionStatus
+	(SortOrder)(0),                   // 1: attendance.SortOrder
+	(DayStatus)(0),                   // 2: attendance.DayStatus
+	(ShiftStatus)(0),                 // 3: attendance.ShiftStatus
+	(DayOfWeek)(0),                   // 4: attendance.DayOfWeek
+	(*CheckInRequest)(nil),           // 5: attendance.CheckInRequest
+	(*CheckOutRequest)(nil),          // 6: attendance.CheckOutRequest
+	(*CheckOutUserRequest)(nil),      // 7: attendance.CheckOutUserRequest
+	(*StartBreakRequest)(nil),        // 8: attendance.StartBreakRequest
+	(*EndBreakRequest)(nil),          // 9: attendance.EndBreakRequest
+	(*GetAttendanceRequest)(nil),     // 10: attendance.GetAttendanceRequest
+	(*GetAllAttendanceRequest)(nil),  // 11: attendance.GetAllAttendanceRequest
+	(*ListAttendanceRequest)(nil),    // 12: attendance.ListAttendanceRequest
+	(*StreamAttendanceRequest)(nil),  // 13: attendance.StreamAttendanceRequest
+	(*GetUserSummaryRequest)(nil),    // 14: attendance.GetUserSummaryRequest
+	(*GetDailyReportRequest)(nil),    // 15: attendance.GetDailyReportRequest
+	(*AttendanceRecordResponse)(nil), // 16: attendance.AttendanceRecordResponse
+	(*Break)(nil),                    // 17: attendance.Break
+	(*GetAllAttendanceResponse)(nil), // 18: attendance.GetAllAttendanceResponse
+	(*ListAttendanceResponse)(nil),   // 19: attendance.ListAttendanceResponse
+	(*DaySummary)(nil),               // 20: attendance.DaySummary
+	(*GetUserSummaryResponse)(nil),   // 21: attendance.GetUserSummaryResponse
+	(*UserDayReport)(nil),            // 22: attendance.UserDayReport
+	(*GetDailyReportResponse)(nil),   // 23: attendance.GetDailyReportResponse
+	(*User)(nil),                     // 24: attendance.User
+	(*CreateUserRequest)(nil),        // 25: attendance.CreateUserRequest
+	(*GetUserRequest)(nil),           // 26: attendance.GetUserRequest
+	(*UpdateUserRequest)(nil),        // 27: attendance.UpdateUserRequest
+	(*DeactivateUserRequest)(nil),    // 28: attendance.DeactivateUserRequest
+	(*ListUsersRequest)(nil),         // 29: attendance.ListUsersRequest
+	(*ListUsersResponse)(nil),        // 30: attendance.ListUsersResponse
+	(*ApiKey)(nil),                   // 31: attendance.ApiKey
+	(*CreateApiKeyRequest)(nil),      // 32: attendance.CreateApiKeyRequest
+	(*CreateApiKeyResponse)(nil),     // 33: attendance.CreateApiKeyResponse
+	(*ListApiKeysRequest)(nil),       // 34: attendance.ListApiKeysRequest
+	(*ListApiKeysResponse)(nil),      // 35: attendance.ListApiKeysResponse
+	(*RevokeApiKeyRequest)(nil),      // 36: attendance.RevokeApiKeyRequest
+	(*Shift)(nil),                    // 37: attendance.Shift
+	(*GetShiftRequest)(nil),          // 38: attendance.GetShiftRequest
+	(*DeleteShiftRequest)(nil),       // 39: attendance.DeleteShiftRequest
+	(*DeleteShiftResponse)(nil),      // 40: attendance.DeleteShiftResponse
+	(*ListShiftsRequest)(nil),        // 41: attendance.ListShiftsRequest
+	(*ListShiftsResponse)(nil),       // 42: attendance.ListShiftsResponse
+	(*AssignShiftRequest)(nil),       // 43: attendance.AssignShiftRequest
+	(*timestamppb.Timestamp)(nil),    // 44: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),      // 45: google.protobuf.Duration
 }
 var file_attendance_proto_depIdxs = []int32{
-	4, // 0: attendance.GetAllAttendanceResponse.records:type_name -> attendance.AttendanceRecordResponse
-	0, // 1: attendance.AttendanceService.CheckIn:input_type -> attendance.CheckInRequest
-	1, // 2: attendance.AttendanceService.CheckOut:input_type -> attendance.CheckOutRequest
-	2, // 3: attendance.AttendanceService.GetAttendance:input_type -> attendance.GetAttendanceRequest
-	3, // 4: attendance.AttendanceService.GetAllAttendance:input_type -> attendance.GetAllAttendanceRequest
-	4, // 5: attendance.AttendanceService.CheckIn:output_type -> attendance.AttendanceRecordResponse
-	4, // 6: attendance.AttendanceService.CheckOut:output_type -> attendance.AttendanceRecordResponse
-	4, // 7: attendance.AttendanceService.GetAttendance:output_type -> attendance.AttendanceRecordResponse
-	5, // 8: attendance.AttendanceService.GetAllAttendance:output_type -> attendance.GetAllAttendanceResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: attendance.ListAttendanceRequest.status:type_name -> attendance.SessionStatus
+	1,  // 1: attendance.ListAttendanceRequest.sort:type_name -> attendance.SortOrder
+	0,  // 2: attendance.StreamAttendanceRequest.status:type_name -> attendance.SessionStatus
+	1,  // 3: attendance.StreamAttendanceRequest.sort:type_name -> attendance.SortOrder
+	44, // 4: attendance.AttendanceRecordResponse.checkin_at:type_name -> google.protobuf.Timestamp
+	44, // 5: attendance.AttendanceRecordResponse.checkout_at:type_name -> google.protobuf.Timestamp
+	45, // 6: attendance.AttendanceRecordResponse.worked:type_name -> google.protobuf.Duration
+	3,  // 7: attendance.AttendanceRecordResponse.shift_status:type_name -> attendance.ShiftStatus
+	45, // 8: attendance.AttendanceRecordResponse.late_by:type_name -> google.protobuf.Duration
+	45, // 9: attendance.AttendanceRecordResponse.left_early_by:type_name -> google.protobuf.Duration
+	17, // 10: attendance.AttendanceRecordResponse.breaks:type_name -> attendance.Break
+	45, // 11: attendance.AttendanceRecordResponse.break_time:type_name -> google.protobuf.Duration
+	44, // 12: attendance.Break.start_at:type_name -> google.protobuf.Timestamp
+	44, // 13: attendance.Break.end_at:type_name -> google.protobuf.Timestamp
+	45, // 14: attendance.Break.duration:type_name -> google.protobuf.Duration
+	16, // 15: attendance.GetAllAttendanceResponse.records:type_name -> attendance.AttendanceRecordResponse
+	16, // 16: attendance.ListAttendanceResponse.records:type_name -> attendance.AttendanceRecordResponse
+	44, // 17: attendance.DaySummary.first_in:type_name -> google.protobuf.Timestamp
+	44, // 18: attendance.DaySummary.last_out:type_name -> google.protobuf.Timestamp
+	45, // 19: attendance.DaySummary.worked:type_name -> google.protobuf.Duration
+	20, // 20: attendance.GetUserSummaryResponse.days:type_name -> attendance.DaySummary
+	45, // 21: attendance.GetUserSummaryResponse.total_worked:type_name -> google.protobuf.Duration
+	2,  // 22: attendance.UserDayReport.status:type_name -> attendance.DayStatus
+	44, // 23: attendance.UserDayReport.first_in:type_name -> google.protobuf.Timestamp
+	44, // 24: attendance.UserDayReport.last_out:type_name -> google.protobuf.Timestamp
+	45, // 25: attendance.UserDayReport.worked:type_name -> google.protobuf.Duration
+	22, // 26: attendance.GetDailyReportResponse.users:type_name -> attendance.UserDayReport
+	44, // 27: attendance.User.created_at:type_name -> google.protobuf.Timestamp
+	44, // 28: attendance.User.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 29: attendance.ListUsersResponse.users:type_name -> attendance.User
+	44, // 30: attendance.ApiKey.created_at:type_name -> google.protobuf.Timestamp
+	44, // 31: attendance.ApiKey.revoked_at:type_name -> google.protobuf.Timestamp
+	31, // 32: attendance.CreateApiKeyResponse.api_key:type_name -> attendance.ApiKey
+	31, // 33: attendance.ListApiKeysResponse.api_keys:type_name -> attendance.ApiKey
+	45, // 34: attendance.Shift.grace_period:type_name -> google.protobuf.Duration
+	4,  // 35: attendance.Shift.days_of_week:type_name -> attendance.DayOfWeek
+	44, // 36: attendance.Shift.created_at:type_name -> google.protobuf.Timestamp
+	44, // 37: attendance.Shift.updated_at:type_name -> google.protobuf.Timestamp
+	37, // 38: attendance.ListShiftsResponse.shifts:type_name -> attendance.Shift
+	5,  // 39: attendance.AttendanceService.CheckIn:input_type -> attendance.CheckInRequest
+	6,  // 40: attendance.AttendanceService.CheckOut:input_type -> attendance.CheckOutRequest
+	7,  // 41: attendance.AttendanceService.CheckOutUser:input_type -> attendance.CheckOutUserRequest
+	8,  // 42: attendance.AttendanceService.StartBreak:input_type -> attendance.StartBreakRequest
+	9,  // 43: attendance.AttendanceService.EndBreak:input_type -> attendance.EndBreakRequest
+	10, // 44: attendance.AttendanceService.GetAttendance:input_type -> attendance.GetAttendanceRequest
+	11, // 45: attendance.AttendanceService.GetAllAttendance:input_type -> attendance.GetAllAttendanceRequest
+	12, // 46: attendance.AttendanceService.ListAttendance:input_type -> attendance.ListAttendanceRequest
+	14, // 47: attendance.AttendanceService.GetUserSummary:input_type -> attendance.GetUserSummaryRequest
+	15, // 48: attendance.AttendanceService.GetDailyReport:input_type -> attendance.GetDailyReportRequest
+	13, // 49: attendance.AttendanceService.StreamAttendance:input_type -> attendance.StreamAttendanceRequest
+	25, // 50: attendance.UserService.CreateUser:input_type -> attendance.CreateUserRequest
+	26, // 51: attendance.UserService.GetUser:input_type -> attendance.GetUserRequest
+	27, // 52: attendance.UserService.UpdateUser:input_type -> attendance.UpdateUserRequest
+	28, // 53: attendance.UserService.DeactivateUser:input_type -> attendance.DeactivateUserRequest
+	29, // 54: attendance.UserService.ListUsers:input_type -> attendance.ListUsersRequest
+	32, // 55: attendance.ApiKeyService.CreateApiKey:input_type -> attendance.CreateApiKeyRequest
+	34, // 56: attendance.ApiKeyService.ListApiKeys:input_type -> attendance.ListApiKeysRequest
+	36, // 57: attendance.ApiKeyService.RevokeApiKey:input_type -> attendance.RevokeApiKeyRequest
+	37, // 58: attendance.ShiftService.CreateShift:input_type -> attendance.Shift
+	38, // 59: attendance.ShiftService.GetShift:input_type -> attendance.GetShiftRequest
+	37, // 60: attendance.ShiftService.UpdateShift:input_type -> attendance.Shift
+	39, // 61: attendance.ShiftService.DeleteShift:input_type -> attendance.DeleteShiftRequest
+	41, // 62: attendance.ShiftService.ListShifts:input_type -> attendance.ListShiftsRequest
+	43, // 63: attendance.ShiftService.AssignShift:input_type -> attendance.AssignShiftRequest
+	16, // 64: attendance.AttendanceService.CheckIn:output_type -> attendance.AttendanceRecordResponse
+	16, // 65: attendance.AttendanceService.CheckOut:output_type -> attendance.AttendanceRecordResponse
+	16, // 66: attendance.AttendanceService.CheckOutUser:output_type -> attendance.AttendanceRecordResponse
+	16, // 67: attendance.AttendanceService.StartBreak:output_type -> attendance.AttendanceRecordResponse
+	16, // 68: attendance.AttendanceService.EndBreak:output_type -> attendance.AttendanceRecordResponse
+	16, // 69: attendance.AttendanceService.GetAttendance:output_type -> attendance.AttendanceRecordResponse
+	18, // 70: attendance.AttendanceService.GetAllAttendance:output_type -> attendance.GetAllAttendanceResponse
+	19, // 71: attendance.AttendanceService.ListAttendance:output_type -> attendance.ListAttendanceResponse
+	21, // 72: attendance.AttendanceService.GetUserSummary:output_type -> attendance.GetUserSummaryResponse
+	23, // 73: attendance.AttendanceService.GetDailyReport:output_type -> attendance.GetDailyReportResponse
+	16, // 74: attendance.AttendanceService.StreamAttendance:output_type -> attendance.AttendanceRecordResponse
+	24, // 75: attendance.UserService.CreateUser:output_type -> attendance.User
+	24, // 76: attendance.UserService.GetUser:output_type -> attendance.User
+	24, // 77: attendance.UserService.UpdateUser:output_type -> attendance.User
+	24, // 78: attendance.UserService.DeactivateUser:output_type -> attendance.User
+	30, // 79: attendance.UserService.ListUsers:output_type -> attendance.ListUsersResponse
+	33, // 80: attendance.ApiKeyService.CreateApiKey:output_type -> attendance.CreateApiKeyResponse
+	35, // 81: attendance.ApiKeyService.ListApiKeys:output_type -> attendance.ListApiKeysResponse
+	31, // 82: attendance.ApiKeyService.RevokeApiKey:output_type -> attendance.ApiKey
+	37, // 83: attendance.ShiftService.CreateShift:output_type -> attendance.Shift
+	37, // 84: attendance.ShiftService.GetShift:output_type -> attendance.Shift
+	37, // 85: attendance.ShiftService.UpdateShift:output_type -> attendance.Shift
+	40, // 86: attendance.ShiftService.DeleteShift:output_type -> attendance.DeleteShiftResponse
+	42, // 87: attendance.ShiftService.ListShifts:output_type -> attendance.ListShiftsResponse
+	24, // 88: attendance.ShiftService.AssignShift:output_type -> attendance.User
+	64, // [64:89] is the sub-list for method output_type
+	39, // [39:64] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_attendance_proto_init() }
@@ -400,18 +3269,20 @@ func file_attendance_proto_init() {
 	if File_attendance_proto != nil {
 		return
 	}
+	file_attendance_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_attendance_proto_rawDesc), len(file_attendance_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      5,
+			NumMessages:   39,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   4,
 		},
 		GoTypes:           file_attendance_proto_goTypes,
 		DependencyIndexes: file_attendance_proto_depIdxs,
+		EnumInfos:         file_attendance_proto_enumTypes,
 		MessageInfos:      file_attendance_proto_msgTypes,
 	}.Build()
 	File_attendance_proto = out.File
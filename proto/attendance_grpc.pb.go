@@ -21,8 +21,15 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	AttendanceService_CheckIn_FullMethodName          = "/attendance.AttendanceService/CheckIn"
 	AttendanceService_CheckOut_FullMethodName         = "/attendance.AttendanceService/CheckOut"
+	AttendanceService_CheckOutUser_FullMethodName     = "/attendance.AttendanceService/CheckOutUser"
+	AttendanceService_StartBreak_FullMethodName       = "/attendance.AttendanceService/StartBreak"
+	AttendanceService_EndBreak_FullMethodName         = "/attendance.AttendanceService/EndBreak"
 	AttendanceService_GetAttendance_FullMethodName    = "/attendance.AttendanceService/GetAttendance"
 	AttendanceService_GetAllAttendance_FullMethodName = "/attendance.AttendanceService/GetAllAttendance"
+	AttendanceService_ListAttendance_FullMethodName   = "/attendance.AttendanceService/ListAttendance"
+	AttendanceService_GetUserSummary_FullMethodName   = "/attendance.AttendanceService/GetUserSummary"
+	AttendanceService_GetDailyReport_FullMethodName   = "/attendance.AttendanceService/GetDailyReport"
+	AttendanceService_StreamAttendance_FullMethodName = "/attendance.AttendanceService/StreamAttendance"
 )
 
 // AttendanceServiceClient is the client API for AttendanceService service.
@@ -33,8 +40,21 @@ const (
 type AttendanceServiceClient interface {
 	CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error)
 	CheckOut(ctx context.Context, in *CheckOutRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error)
+	CheckOutUser(ctx context.Context, in *CheckOutUserRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error)
+	// Breaks apply to the user's open session; checking out ends a running
+	// break.
+	StartBreak(ctx context.Context, in *StartBreakRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error)
+	EndBreak(ctx context.Context, in *EndBreakRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error)
 	GetAttendance(ctx context.Context, in *GetAttendanceRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error)
+	// Deprecated: Do not use.
+	// Deprecated: returns every record in one message; use ListAttendance.
 	GetAllAttendance(ctx context.Context, in *GetAllAttendanceRequest, opts ...grpc.CallOption) (*GetAllAttendanceResponse, error)
+	ListAttendance(ctx context.Context, in *ListAttendanceRequest, opts ...grpc.CallOption) (*ListAttendanceResponse, error)
+	GetUserSummary(ctx context.Context, in *GetUserSummaryRequest, opts ...grpc.CallOption) (*GetUserSummaryResponse, error)
+	GetDailyReport(ctx context.Context, in *GetDailyReportRequest, opts ...grpc.CallOption) (*GetDailyReportResponse, error)
+	// Streams every matching record; over REST the response is
+	// newline-delimited JSON.
+	StreamAttendance(ctx context.Context, in *StreamAttendanceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttendanceRecordResponse], error)
 }
 
 type attendanceServiceClient struct {
@@ -65,6 +85,36 @@ func (c *attendanceServiceClient) CheckOut(ctx context.Context, in *CheckOutRequ
 	return out, nil
 }
 
+func (c *attendanceServiceClient) CheckOutUser(ctx context.Context, in *CheckOutUserRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttendanceRecordResponse)
+	err := c.cc.Invoke(ctx, AttendanceService_CheckOutUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceServiceClient) StartBreak(ctx context.Context, in *StartBreakRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttendanceRecordResponse)
+	err := c.cc.Invoke(ctx, AttendanceService_StartBreak_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceServiceClient) EndBreak(ctx context.Context, in *EndBreakRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttendanceRecordResponse)
+	err := c.cc.Invoke(ctx, AttendanceService_EndBreak_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *attendanceServiceClient) GetAttendance(ctx context.Context, in *GetAttendanceRequest, opts ...grpc.CallOption) (*AttendanceRecordResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AttendanceRecordResponse)
@@ -75,6 +125,7 @@ func (c *attendanceServiceClient) GetAttendance(ctx context.Context, in *GetAtte
 	return out, nil
 }
 
+// Deprecated: Do not use.
 func (c *attendanceServiceClient) GetAllAttendance(ctx context.Context, in *GetAllAttendanceRequest, opts ...grpc.CallOption) (*GetAllAttendanceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetAllAttendanceResponse)
@@ -85,6 +136,55 @@ func (c *attendanceServiceClient) GetAllAttendance(ctx context.Context, in *GetA
 	return out, nil
 }
 
+func (c *attendanceServiceClient) ListAttendance(ctx context.Context, in *ListAttendanceRequest, opts ...grpc.CallOption) (*ListAttendanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttendanceResponse)
+	err := c.cc.Invoke(ctx, AttendanceService_ListAttendance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceServiceClient) GetUserSummary(ctx context.Context, in *GetUserSummaryRequest, opts ...grpc.CallOption) (*GetUserSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserSummaryResponse)
+	err := c.cc.Invoke(ctx, AttendanceService_GetUserSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceServiceClient) GetDailyReport(ctx context.Context, in *GetDailyReportRequest, opts ...grpc.CallOption) (*GetDailyReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDailyReportResponse)
+	err := c.cc.Invoke(ctx, AttendanceService_GetDailyReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceServiceClient) StreamAttendance(ctx context.Context, in *StreamAttendanceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttendanceRecordResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AttendanceService_ServiceDesc.Streams[0], AttendanceService_StreamAttendance_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamAttendanceRequest, AttendanceRecordResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AttendanceService_StreamAttendanceClient = grpc.ServerStreamingClient[AttendanceRecordResponse]
+
 // AttendanceServiceServer is the server API for AttendanceService service.
 // All implementations must embed UnimplementedAttendanceServiceServer
 // for forward compatibility.
@@ -93,8 +193,21 @@ func (c *attendanceServiceClient) GetAllAttendance(ctx context.Context, in *GetA
 type AttendanceServiceServer interface {
 	CheckIn(context.Context, *CheckInRequest) (*AttendanceRecordResponse, error)
 	CheckOut(context.Context, *CheckOutRequest) (*AttendanceRecordResponse, error)
+	CheckOutUser(context.Context, *CheckOutUserRequest) (*AttendanceRecordResponse, error)
+	// Breaks apply to the user's open session; checking out ends a running
+	// break.
+	StartBreak(context.Context, *StartBreakRequest) (*AttendanceRecordResponse, error)
+	EndBreak(context.Context, *EndBreakRequest) (*AttendanceRecordResponse, error)
 	GetAttendance(context.Context, *GetAttendanceRequest) (*AttendanceRecordResponse, error)
+	// Deprecated: Do not use.
+	// Deprecated: returns every record in one message; use ListAttendance.
 	GetAllAttendance(context.Context, *GetAllAttendanceRequest) (*GetAllAttendanceResponse, error)
+	ListAttendance(context.Context, *ListAttendanceRequest) (*ListAttendanceResponse, error)
+	GetUserSummary(context.Context, *GetUserSummaryRequest) (*GetUserSummaryResponse, error)
+	GetDailyReport(context.Context, *GetDailyReportRequest) (*GetDailyReportResponse, error)
+	// Streams every matching record; over REST the response is
+	// newline-delimited JSON.
+	StreamAttendance(*StreamAttendanceRequest, grpc.ServerStreamingServer[AttendanceRecordResponse]) error
 	mustEmbedUnimplementedAttendanceServiceServer()
 }
 
@@ -111,12 +224,33 @@ func (UnimplementedAttendanceServiceServer) CheckIn(context.Context, *CheckInReq
 func (UnimplementedAttendanceServiceServer) CheckOut(context.Context, *CheckOutRequest) (*AttendanceRecordResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckOut not implemented")
 }
+func (UnimplementedAttendanceServiceServer) CheckOutUser(context.Context, *CheckOutUserRequest) (*AttendanceRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckOutUser not implemented")
+}
+func (UnimplementedAttendanceServiceServer) StartBreak(context.Context, *StartBreakRequest) (*AttendanceRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBreak not implemented")
+}
+func (UnimplementedAttendanceServiceServer) EndBreak(context.Context, *EndBreakRequest) (*AttendanceRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndBreak not implemented")
+}
 func (UnimplementedAttendanceServiceServer) GetAttendance(context.Context, *GetAttendanceRequest) (*AttendanceRecordResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAttendance not implemented")
 }
 func (UnimplementedAttendanceServiceServer) GetAllAttendance(context.Context, *GetAllAttendanceRequest) (*GetAllAttendanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllAttendance not implemented")
 }
+func (UnimplementedAttendanceServiceServer) ListAttendance(context.Context, *ListAttendanceRequest) (*ListAttendanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttendance not implemented")
+}
+func (UnimplementedAttendanceServiceServer) GetUserSummary(context.Context, *GetUserSummaryRequest) (*GetUserSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserSummary not implemented")
+}
+func (UnimplementedAttendanceServiceServer) GetDailyReport(context.Context, *GetDailyReportRequest) (*GetDailyReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyReport not implemented")
+}
+func (UnimplementedAttendanceServiceServer) StreamAttendance(*StreamAttendanceRequest, grpc.ServerStreamingServer[AttendanceRecordResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAttendance not implemented")
+}
 func (UnimplementedAttendanceServiceServer) mustEmbedUnimplementedAttendanceServiceServer() {}
 func (UnimplementedAttendanceServiceServer) testEmbeddedByValue()                           {}
 
@@ -174,6 +308,60 @@ func _AttendanceService_CheckOut_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AttendanceService_CheckOutUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckOutUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceServiceServer).CheckOutUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceService_CheckOutUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceServiceServer).CheckOutUser(ctx, req.(*CheckOutUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceService_StartBreak_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartBreakRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceServiceServer).StartBreak(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceService_StartBreak_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceServiceServer).StartBreak(ctx, req.(*StartBreakRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceService_EndBreak_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndBreakRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceServiceServer).EndBreak(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceService_EndBreak_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceServiceServer).EndBreak(ctx, req.(*EndBreakRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AttendanceService_GetAttendance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAttendanceRequest)
 	if err := dec(in); err != nil {
@@ -210,6 +398,71 @@ func _AttendanceService_GetAllAttendance_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AttendanceService_ListAttendance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttendanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceServiceServer).ListAttendance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceService_ListAttendance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceServiceServer).ListAttendance(ctx, req.(*ListAttendanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceService_GetUserSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceServiceServer).GetUserSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceService_GetUserSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceServiceServer).GetUserSummary(ctx, req.(*GetUserSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceService_GetDailyReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceServiceServer).GetDailyReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceService_GetDailyReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceServiceServer).GetDailyReport(ctx, req.(*GetDailyReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceService_StreamAttendance_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAttendanceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AttendanceServiceServer).StreamAttendance(m, &grpc.GenericServerStream[StreamAttendanceRequest, AttendanceRecordResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AttendanceService_StreamAttendanceServer = grpc.ServerStreamingServer[AttendanceRecordResponse]
+
 // AttendanceService_ServiceDesc is the grpc.ServiceDesc for AttendanceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -225,6 +478,18 @@ var AttendanceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckOut",
 			Handler:    _AttendanceService_CheckOut_Handler,
 		},
+		{
+			MethodName: "CheckOutUser",
+			Handler:    _AttendanceService_CheckOutUser_Handler,
+		},
+		{
+			MethodName: "StartBreak",
+			Handler:    _AttendanceService_StartBreak_Handler,
+		},
+		{
+			MethodName: "EndBreak",
+			Handler:    _AttendanceService_EndBreak_Handler,
+		},
 		{
 			MethodName: "GetAttendance",
 			Handler:    _AttendanceService_GetAttendance_Handler,
@@ -233,6 +498,752 @@ var AttendanceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllAttendance",
 			Handler:    _AttendanceService_GetAllAttendance_Handler,
 		},
+		{
+			MethodName: "ListAttendance",
+			Handler:    _AttendanceService_ListAttendance_Handler,
+		},
+		{
+			MethodName: "GetUserSummary",
+			Handler:    _AttendanceService_GetUserSummary_Handler,
+		},
+		{
+			MethodName: "GetDailyReport",
+			Handler:    _AttendanceService_GetDailyReport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAttendance",
+			Handler:       _AttendanceService_StreamAttendance_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "attendance.proto",
+}
+
+const (
+	UserService_CreateUser_FullMethodName     = "/attendance.UserService/CreateUser"
+	UserService_GetUser_FullMethodName        = "/attendance.UserService/GetUser"
+	UserService_UpdateUser_FullMethodName     = "/attendance.UserService/UpdateUser"
+	UserService_DeactivateUser_FullMethodName = "/attendance.UserService/DeactivateUser"
+	UserService_ListUsers_FullMethodName      = "/attendance.UserService/ListUsers"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserServiceClient interface {
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error)
+	DeactivateUser(ctx context.Context, in *DeactivateUserRequest, opts ...grpc.CallOption) (*User, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_UpdateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeactivateUser(ctx context.Context, in *DeactivateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_DeactivateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+type UserServiceServer interface {
+	CreateUser(context.Context, *CreateUserRequest) (*User, error)
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	UpdateUser(context.Context, *UpdateUserRequest) (*User, error)
+	DeactivateUser(context.Context, *DeactivateUserRequest) (*User, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) CreateUser(context.Context, *CreateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedUserServiceServer) DeactivateUser(context.Context, *DeactivateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateUser not implemented")
+}
+func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeactivateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeactivateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeactivateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeactivateUser(ctx, req.(*DeactivateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "attendance.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUser",
+			Handler:    _UserService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _UserService_UpdateUser_Handler,
+		},
+		{
+			MethodName: "DeactivateUser",
+			Handler:    _UserService_DeactivateUser_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _UserService_ListUsers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "attendance.proto",
+}
+
+const (
+	ApiKeyService_CreateApiKey_FullMethodName = "/attendance.ApiKeyService/CreateApiKey"
+	ApiKeyService_ListApiKeys_FullMethodName  = "/attendance.ApiKeyService/ListApiKeys"
+	ApiKeyService_RevokeApiKey_FullMethodName = "/attendance.ApiKeyService/RevokeApiKey"
+)
+
+// ApiKeyServiceClient is the client API for ApiKeyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ApiKeyServiceClient interface {
+	CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error)
+	ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error)
+	RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*ApiKey, error)
+}
+
+type apiKeyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewApiKeyServiceClient(cc grpc.ClientConnInterface) ApiKeyServiceClient {
+	return &apiKeyServiceClient{cc}
+}
+
+func (c *apiKeyServiceClient) CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateApiKeyResponse)
+	err := c.cc.Invoke(ctx, ApiKeyService_CreateApiKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiKeyServiceClient) ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListApiKeysResponse)
+	err := c.cc.Invoke(ctx, ApiKeyService_ListApiKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiKeyServiceClient) RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*ApiKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiKey)
+	err := c.cc.Invoke(ctx, ApiKeyService_RevokeApiKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApiKeyServiceServer is the server API for ApiKeyService service.
+// All implementations must embed UnimplementedApiKeyServiceServer
+// for forward compatibility.
+type ApiKeyServiceServer interface {
+	CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error)
+	ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error)
+	RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*ApiKey, error)
+	mustEmbedUnimplementedApiKeyServiceServer()
+}
+
+// UnimplementedApiKeyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedApiKeyServiceServer struct{}
+
+func (UnimplementedApiKeyServiceServer) CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiKey not implemented")
+}
+func (UnimplementedApiKeyServiceServer) ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApiKeys not implemented")
+}
+func (UnimplementedApiKeyServiceServer) RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*ApiKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeApiKey not implemented")
+}
+func (UnimplementedApiKeyServiceServer) mustEmbedUnimplementedApiKeyServiceServer() {}
+func (UnimplementedApiKeyServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeApiKeyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ApiKeyServiceServer will
+// result in compilation errors.
+type UnsafeApiKeyServiceServer interface {
+	mustEmbedUnimplementedApiKeyServiceServer()
+}
+
+func RegisterApiKeyServiceServer(s grpc.ServiceRegistrar, srv ApiKeyServiceServer) {
+	// If the following call pancis, it indicates UnimplementedApiKeyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ApiKeyService_ServiceDesc, srv)
+}
+
+func _ApiKeyService_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiKeyServiceServer).CreateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApiKeyService_CreateApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiKeyServiceServer).CreateApiKey(ctx, req.(*CreateApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiKeyService_ListApiKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiKeyServiceServer).ListApiKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApiKeyService_ListApiKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiKeyServiceServer).ListApiKeys(ctx, req.(*ListApiKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiKeyService_RevokeApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiKeyServiceServer).RevokeApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApiKeyService_RevokeApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiKeyServiceServer).RevokeApiKey(ctx, req.(*RevokeApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ApiKeyService_ServiceDesc is the grpc.ServiceDesc for ApiKeyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ApiKeyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "attendance.ApiKeyService",
+	HandlerType: (*ApiKeyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateApiKey",
+			Handler:    _ApiKeyService_CreateApiKey_Handler,
+		},
+		{
+			MethodName: "ListApiKeys",
+			Handler:    _ApiKeyService_ListApiKeys_Handler,
+		},
+		{
+			MethodName: "RevokeApiKey",
+			Handler:    _ApiKeyService_RevokeApiKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "attendance.proto",
+}
+
+const (
+	ShiftService_CreateShift_FullMethodName = "/attendance.ShiftService/CreateShift"
+	ShiftService_GetShift_FullMethodName    = "/attendance.ShiftService/GetShift"
+	ShiftService_UpdateShift_FullMethodName = "/attendance.ShiftService/UpdateShift"
+	ShiftService_DeleteShift_FullMethodName = "/attendance.ShiftService/DeleteShift"
+	ShiftService_ListShifts_FullMethodName  = "/attendance.ShiftService/ListShifts"
+	ShiftService_AssignShift_FullMethodName = "/attendance.ShiftService/AssignShift"
+)
+
+// ShiftServiceClient is the client API for ShiftService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShiftServiceClient interface {
+	CreateShift(ctx context.Context, in *Shift, opts ...grpc.CallOption) (*Shift, error)
+	GetShift(ctx context.Context, in *GetShiftRequest, opts ...grpc.CallOption) (*Shift, error)
+	// Replaces every field of the shift.
+	UpdateShift(ctx context.Context, in *Shift, opts ...grpc.CallOption) (*Shift, error)
+	// Fails with FAILED_PRECONDITION while users are assigned to the shift.
+	DeleteShift(ctx context.Context, in *DeleteShiftRequest, opts ...grpc.CallOption) (*DeleteShiftResponse, error)
+	ListShifts(ctx context.Context, in *ListShiftsRequest, opts ...grpc.CallOption) (*ListShiftsResponse, error)
+	AssignShift(ctx context.Context, in *AssignShiftRequest, opts ...grpc.CallOption) (*User, error)
+}
+
+type shiftServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShiftServiceClient(cc grpc.ClientConnInterface) ShiftServiceClient {
+	return &shiftServiceClient{cc}
+}
+
+func (c *shiftServiceClient) CreateShift(ctx context.Context, in *Shift, opts ...grpc.CallOption) (*Shift, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shift)
+	err := c.cc.Invoke(ctx, ShiftService_CreateShift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shiftServiceClient) GetShift(ctx context.Context, in *GetShiftRequest, opts ...grpc.CallOption) (*Shift, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shift)
+	err := c.cc.Invoke(ctx, ShiftService_GetShift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shiftServiceClient) UpdateShift(ctx context.Context, in *Shift, opts ...grpc.CallOption) (*Shift, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shift)
+	err := c.cc.Invoke(ctx, ShiftService_UpdateShift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shiftServiceClient) DeleteShift(ctx context.Context, in *DeleteShiftRequest, opts ...grpc.CallOption) (*DeleteShiftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteShiftResponse)
+	err := c.cc.Invoke(ctx, ShiftService_DeleteShift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shiftServiceClient) ListShifts(ctx context.Context, in *ListShiftsRequest, opts ...grpc.CallOption) (*ListShiftsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShiftsResponse)
+	err := c.cc.Invoke(ctx, ShiftService_ListShifts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shiftServiceClient) AssignShift(ctx context.Context, in *AssignShiftRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, ShiftService_AssignShift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShiftServiceServer is the server API for ShiftService service.
+// All implementations must embed UnimplementedShiftServiceServer
+// for forward compatibility.
+type ShiftServiceServer interface {
+	CreateShift(context.Context, *Shift) (*Shift, error)
+	GetShift(context.Context, *GetShiftRequest) (*Shift, error)
+	// Replaces every field of the shift.
+	UpdateShift(context.Context, *Shift) (*Shift, error)
+	// Fails with FAILED_PRECONDITION while users are assigned to the shift.
+	DeleteShift(context.Context, *DeleteShiftRequest) (*DeleteShiftResponse, error)
+	ListShifts(context.Context, *ListShiftsRequest) (*ListShiftsResponse, error)
+	AssignShift(context.Context, *AssignShiftRequest) (*User, error)
+	mustEmbedUnimplementedShiftServiceServer()
+}
+
+// UnimplementedShiftServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShiftServiceServer struct{}
+
+func (UnimplementedShiftServiceServer) CreateShift(context.Context, *Shift) (*Shift, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShift not implemented")
+}
+func (UnimplementedShiftServiceServer) GetShift(context.Context, *GetShiftRequest) (*Shift, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShift not implemented")
+}
+func (UnimplementedShiftServiceServer) UpdateShift(context.Context, *Shift) (*Shift, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShift not implemented")
+}
+func (UnimplementedShiftServiceServer) DeleteShift(context.Context, *DeleteShiftRequest) (*DeleteShiftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShift not implemented")
+}
+func (UnimplementedShiftServiceServer) ListShifts(context.Context, *ListShiftsRequest) (*ListShiftsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShifts not implemented")
+}
+func (UnimplementedShiftServiceServer) AssignShift(context.Context, *AssignShiftRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignShift not implemented")
+}
+func (UnimplementedShiftServiceServer) mustEmbedUnimplementedShiftServiceServer() {}
+func (UnimplementedShiftServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeShiftServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShiftServiceServer will
+// result in compilation errors.
+type UnsafeShiftServiceServer interface {
+	mustEmbedUnimplementedShiftServiceServer()
+}
+
+func RegisterShiftServiceServer(s grpc.ServiceRegistrar, srv ShiftServiceServer) {
+	// If the following call pancis, it indicates UnimplementedShiftServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ShiftService_ServiceDesc, srv)
+}
+
+func _ShiftService_CreateShift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Shift)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShiftServiceServer).CreateShift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShiftService_CreateShift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShiftServiceServer).CreateShift(ctx, req.(*Shift))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShiftService_GetShift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShiftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShiftServiceServer).GetShift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShiftService_GetShift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShiftServiceServer).GetShift(ctx, req.(*GetShiftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShiftService_UpdateShift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Shift)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShiftServiceServer).UpdateShift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShiftService_UpdateShift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShiftServiceServer).UpdateShift(ctx, req.(*Shift))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShiftService_DeleteShift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShiftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShiftServiceServer).DeleteShift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShiftService_DeleteShift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShiftServiceServer).DeleteShift(ctx, req.(*DeleteShiftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShiftService_ListShifts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShiftsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShiftServiceServer).ListShifts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShiftService_ListShifts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShiftServiceServer).ListShifts(ctx, req.(*ListShiftsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShiftService_AssignShift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignShiftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShiftServiceServer).AssignShift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShiftService_AssignShift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShiftServiceServer).AssignShift(ctx, req.(*AssignShiftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ShiftService_ServiceDesc is the grpc.ServiceDesc for ShiftService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ShiftService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "attendance.ShiftService",
+	HandlerType: (*ShiftServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateShift",
+			Handler:    _ShiftService_CreateShift_Handler,
+		},
+		{
+			MethodName: "GetShift",
+			Handler:    _ShiftService_GetShift_Handler,
+		},
+		{
+			MethodName: "UpdateShift",
+			Handler:    _ShiftService_UpdateShift_Handler,
+		},
+		{
+			MethodName: "DeleteShift",
+			Handler:    _ShiftService_DeleteShift_Handler,
+		},
+		{
+			MethodName: "ListShifts",
+			Handler:    _ShiftService_ListShifts_Handler,
+		},
+		{
+			MethodName: "AssignShift",
+			Handler:    _ShiftService_AssignShift_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "attendance.proto",
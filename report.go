@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *attendanceServer) GetDailyReport(ctx context.Context, req *pb.GetDailyReportRequest) (*pb.GetDailyReportResponse, error) {
+	managerID, err := s.policy.managerScope(ctx, "GetDailyReport")
+	if err != nil {
+		return nil, err
+	}
+	loc, err := s.location(req.GetTimeZone())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	day := startOfDay(now, loc)
+	if d := req.GetDate(); d != "" && d != "today" {
+		day, err = time.ParseInLocation(dateLayout, d, loc)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "date must be YYYY-MM-DD or \"today\"")
+		}
+	}
+
+	totals, err := s.store.DailyTotals(ctx, "", day, day.AddDate(0, 0, 1), loc, now.UTC())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "aggregate error: %v", err)
+	}
+	byUser := make(map[string]DayTotal, len(totals))
+	for _, t := range totals {
+		byUser[t.UserID] = t
+	}
+	// Users deactivated since still appear for the days they worked.
+	roster, err := s.users.List(ctx, UserQuery{ManagerID: managerID, IncludeInactive: true})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "roster error: %v", err)
+	}
+
+	resp := &pb.GetDailyReportResponse{Date: day.Format(dateLayout)}
+	for _, u := range roster {
+		if _, worked := byUser[u.UserID]; !u.Active && !worked {
+			continue
+		}
+		ur := &pb.UserDayReport{
+			UserId:        u.UserID,
+			Username:      u.Username,
+			Status:        pb.DayStatus_DAY_STATUS_ABSENT,
+			Worked:        durationpb.New(0),
+			WorkedDisplay: "0s",
+		}
+		if t, ok := byUser[u.UserID]; ok {
+			worked := t.Worked.Truncate(time.Second)
+			ur.FirstIn = timestamppb.New(t.FirstIn)
+			ur.Sessions = int32(t.Sessions)
+			ur.Worked = durationpb.New(worked)
+			ur.WorkedDisplay = worked.String()
+			if t.StillIn {
+				ur.Status = pb.DayStatus_DAY_STATUS_CHECKED_IN
+			} else {
+				ur.Status = pb.DayStatus_DAY_STATUS_PRESENT
+				ur.LastOut = timestamppb.New(t.LastOut)
+			}
+		}
+		switch ur.Status {
+		case pb.DayStatus_DAY_STATUS_PRESENT:
+			resp.PresentCount++
+		case pb.DayStatus_DAY_STATUS_CHECKED_IN:
+			resp.CheckedInCount++
+		default:
+			resp.AbsentCount++
+		}
+		resp.Users = append(resp.Users, ur)
+	}
+	return resp, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestGetDailyReport(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "present", "still-in", "absent", "left", "left-idle", "elsewhere")
+	m := s.store.(*memoryStore)
+	inactive := false
+	for id, mgr := range map[string]string{
+		"present": "m1", "still-in": "m1", "absent": "m1", "left": "m1", "left-idle": "m1", "elsewhere": "m2",
+	} {
+		mgr := mgr
+		upd := UserUpdate{ManagerID: &mgr}
+		if id == "left" || id == "left-idle" {
+			upd.Active = &inactive
+		}
+		if _, err := s.users.Update(ctx, id, upd, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	insertRecord(t, m, "present", t0, t0.Add(8*time.Hour))
+	insertRecord(t, m, "still-in", t0.Add(time.Hour), time.Time{})
+	insertRecord(t, m, "left", t0, t0.Add(4*time.Hour)) // deactivated after working
+	insertRecord(t, m, "elsewhere", t0, t0.Add(2*time.Hour))
+	insertRecord(t, m, "absent", t0.Add(-48*time.Hour), t0.Add(-40*time.Hour)) // another day
+
+	manager := withPrincipal(ctx, &Principal{Subject: "m1", Roles: []string{RoleManager}})
+	employee := withPrincipal(ctx, &Principal{Subject: "present", Roles: []string{RoleEmployee}})
+
+	for _, tc := range []struct {
+		name                       string
+		ctx                        context.Context
+		users                      map[string]pb.DayStatus
+		present, checkedIn, absent int32
+	}{
+		{"admin sees everyone", ctx, map[string]pb.DayStatus{
+			"absent":    pb.DayStatus_DAY_STATUS_ABSENT,
+			"elsewhere": pb.DayStatus_DAY_STATUS_PRESENT,
+			"left":      pb.DayStatus_DAY_STATUS_PRESENT,
+			"present":   pb.DayStatus_DAY_STATUS_PRESENT,
+			"still-in":  pb.DayStatus_DAY_STATUS_CHECKED_IN,
+		}, 3, 1, 1},
+		{"manager sees their reports", manager, map[string]pb.DayStatus{
+			"absent":   pb.DayStatus_DAY_STATUS_ABSENT,
+			"left":     pb.DayStatus_DAY_STATUS_PRESENT,
+			"present":  pb.DayStatus_DAY_STATUS_PRESENT,
+			"still-in": pb.DayStatus_DAY_STATUS_CHECKED_IN,
+		}, 2, 1, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := s.GetDailyReport(tc.ctx, &pb.GetDailyReportRequest{Date: "2026-03-02"})
+			if err != nil {
+				t.Fatalf("GetDailyReport: %v", err)
+			}
+			if resp.GetPresentCount() != tc.present || resp.GetCheckedInCount() != tc.checkedIn || resp.GetAbsentCount() != tc.absent {
+				t.Errorf("counts = %d present, %d checked in, %d absent; want %d, %d, %d",
+					resp.GetPresentCount(), resp.GetCheckedInCount(), resp.GetAbsentCount(), tc.present, tc.checkedIn, tc.absent)
+			}
+			if len(resp.GetUsers()) != len(tc.users) {
+				t.Errorf("users = %v, want %d", resp.GetUsers(), len(tc.users))
+			}
+			prev := ""
+			for _, u := range resp.GetUsers() {
+				if want, ok := tc.users[u.GetUserId()]; !ok || u.GetStatus() != want {
+					t.Errorf("%s: status %v, want %v (listed: %v)", u.GetUserId(), u.GetStatus(), want, ok)
+				}
+				if u.GetUserId() < prev {
+					t.Errorf("%s listed after %s", u.GetUserId(), prev)
+				}
+				prev = u.GetUserId()
+			}
+		})
+	}
+
+	_, err := s.GetDailyReport(employee, &pb.GetDailyReportRequest{Date: "2026-03-02"})
+	wantCode(t, err, codes.PermissionDenied)
+	_, err = s.GetDailyReport(ctx, &pb.GetDailyReportRequest{Date: "yesterday"})
+	wantCode(t, err, codes.InvalidArgument)
+}
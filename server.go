@@ -2,17 +2,16 @@ package main
 
 import (
 	"context"
-	"log"
 	"time"
 
 	pb "attendance1/proto"
 
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Mongo Model
@@ -22,50 +21,205 @@ type AttendanceRecord struct {
 	Username     string             `bson:"username"`
 	CheckinTime  time.Time          `bson:"checkin_time"`
 	CheckoutTime *time.Time         `bson:"checkout_time,omitempty"`
+	Site         string             `bson:"site,omitempty"`         // kiosk site for API key check-ins
+	Shift        *ShiftOccurrence   `bson:"shift,omitempty"`        // set when the user has a shift
+	AutoClosed   bool               `bson:"auto_closed,omitempty"`  // checked out by the sweeper
+	CloseReason  string             `bson:"close_reason,omitempty"` // why, when AutoClosed
+	Breaks       []Break            `bson:"breaks,omitempty"`
+	// Open is set while CheckoutTime is nil. Mongo partial indexes cannot
+	// filter on a missing field, so the one-open-session index keys on this.
+	Open bool `bson:"open,omitempty"`
+}
+
+// Worked returns the time worked in the session, excluding breaks: up to
+// checkout for closed records, up to now for open ones.
+func (r *AttendanceRecord) Worked(now time.Time) time.Duration {
+	end := now
+	if r.CheckoutTime != nil {
+		end = *r.CheckoutTime
+	}
+	if d := end.Sub(r.CheckinTime) - r.breakTime(r.CheckinTime, end); d > 0 {
+		return d
+	}
+	return 0
 }
 
 // gRPC server struct
 type attendanceServer struct {
 	pb.UnimplementedAttendanceServiceServer
-	collection *mongo.Collection
-	loc        *time.Location
+	store  AttendanceStore
+	users  UserStore
+	shifts ShiftStore
+	policy *accessPolicy
+	loc    *time.Location
 }
 
-// Format IST
+// Format a time for display in the given zone (IST unless configured or
+// requested otherwise)
 func formatIST(t time.Time, loc *time.Location) string {
 	return t.In(loc).Format("2006-01-02 15:04:05 MST")
 }
 
+// toResponse converts a stored record into its API representation, rendering
+// display times in loc.
+func toResponse(r *AttendanceRecord, loc *time.Location, msg string) *pb.AttendanceRecordResponse {
+	resp := &pb.AttendanceRecordResponse{
+		Id:            r.ID.Hex(),
+		UserId:        r.UserID,
+		Username:      r.Username,
+		CheckinTime:   formatIST(r.CheckinTime, loc),
+		CheckinAt:     timestamppb.New(r.CheckinTime),
+		StatusMessage: msg,
+		Site:          r.Site,
+		AutoClosed:    r.AutoClosed,
+		CloseReason:   r.CloseReason,
+	}
+	if r.CheckoutTime != nil {
+		resp.CheckoutTime = formatIST(*r.CheckoutTime, loc)
+		resp.CheckoutAt = timestamppb.New(*r.CheckoutTime)
+	}
+	now := time.Now()
+	worked := r.Worked(now).Truncate(time.Second)
+	resp.Worked = durationpb.New(worked)
+	resp.WorkedDisplay = worked.String()
+	if len(r.Breaks) > 0 {
+		end := now
+		if r.CheckoutTime != nil {
+			end = *r.CheckoutTime
+		}
+		resp.Breaks = toBreakResponses(r, now)
+		resp.BreakTime = durationpb.New(r.breakTime(r.CheckinTime, end).Truncate(time.Second))
+	}
+	if r.Shift != nil {
+		st, late, early := r.Shift.punctuality(r.CheckinTime, r.CheckoutTime)
+		resp.ShiftId, resp.ShiftStatus = r.Shift.ShiftID, st
+		if late > 0 {
+			resp.LateBy = durationpb.New(late.Truncate(time.Second))
+		}
+		if early > 0 {
+			resp.LeftEarlyBy = durationpb.New(early.Truncate(time.Second))
+		}
+	}
+	return resp
+}
+
+// alreadyCheckedIn builds the AlreadyExists error for a user that still has
+// an open session, carrying the open record's id in the error details.
+func alreadyCheckedIn(open *AttendanceRecord) error {
+	st := status.New(codes.AlreadyExists, "user already checked in")
+	ds, err := st.WithDetails(&errdetails.ResourceInfo{
+		ResourceType: "AttendanceRecord",
+		ResourceName: open.ID.Hex(),
+		Description:  "open session for user " + open.UserID,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return ds.Err()
+}
+
+// alreadyCheckedOut builds the FailedPrecondition error for a record that was
+// closed earlier, reporting the original checkout time.
+func alreadyCheckedOut(closed *AttendanceRecord, loc *time.Location) error {
+	at := ""
+	if closed.CheckoutTime != nil {
+		at = formatIST(*closed.CheckoutTime, loc)
+	}
+	st := status.Newf(codes.FailedPrecondition, "record already checked out at %s", at)
+	ds, err := st.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        "ALREADY_CHECKED_OUT",
+			Subject:     closed.ID.Hex(),
+			Description: "checked out at " + at,
+		}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return ds.Err()
+}
+
+// canList checks access for listing records: one user's records need read
+// access to that user, everyone's need admin.
+func (s *attendanceServer) canList(ctx context.Context, userID string) error {
+	if userID == "" {
+		return s.policy.requireAdmin(ctx, "listing records without user_id")
+	}
+	return s.policy.canRead(ctx, userID)
+}
+
 // --- gRPC Methods ---
 func (s *attendanceServer) CheckIn(ctx context.Context, req *pb.CheckInRequest) (*pb.AttendanceRecordResponse, error) {
-	log.Println("[CheckIn]", req)
-	if req.GetUserId() == "" || req.GetUsername() == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id and username required")
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	if err := s.policy.canActAs(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	user, err := s.users.Get(ctx, req.GetUserId())
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "user not registered")
+		}
+		return nil, status.Errorf(codes.Internal, "db error: %v", err)
+	}
+	if !user.Active {
+		return nil, status.Error(codes.FailedPrecondition, "user is deactivated")
+	}
+	tz := req.GetTimeZone()
+	if tz == "" {
+		tz = user.TimeZone
+	}
+	loc, err := s.location(tz)
+	if err != nil {
+		return nil, err
 	}
 
 	rec := AttendanceRecord{
 		ID:          primitive.NewObjectID(),
-		UserID:      req.GetUserId(),
-		Username:    req.GetUsername(),
+		UserID:      user.UserID,
+		Username:    user.Username,
 		CheckinTime: time.Now().UTC(),
 	}
+	if caller, ok := principalFrom(ctx); ok {
+		rec.Site = caller.Site
+	}
+	if user.ShiftID != "" {
+		// DeleteShift checks for assigned users before deleting, so a racing
+		// AssignShift can leave shift_id naming a deleted shift: no shift.
+		sh, err := s.shifts.Get(ctx, user.ShiftID)
+		if err != nil && err != ErrNotFound {
+			return nil, status.Errorf(codes.Internal, "db error: %v", err)
+		}
+		if err == nil {
+			rec.Shift = sh.occurrence(rec.CheckinTime, sh.location(s.loc))
+		}
+	}
 
-	_, err := s.collection.InsertOne(ctx, rec)
-	if err != nil {
+	if open, err := s.store.OpenByUser(ctx, rec.UserID); err == nil {
+		duplicateCheckIns.Inc()
+		return nil, alreadyCheckedIn(open)
+	} else if err != ErrNotFound {
+		return nil, status.Errorf(codes.Internal, "db error: %v", err)
+	}
+
+	if err := s.store.Insert(ctx, &rec); err != nil {
+		if err == ErrOpenSession {
+			// Lost a race with a concurrent check-in for the same user.
+			duplicateCheckIns.Inc()
+			if open, ferr := s.store.OpenByUser(ctx, rec.UserID); ferr == nil {
+				return nil, alreadyCheckedIn(open)
+			}
+			return nil, status.Error(codes.AlreadyExists, "user already checked in")
+		}
 		return nil, status.Errorf(codes.Internal, "insert error: %v", err)
 	}
 
-	return &pb.AttendanceRecordResponse{
-		Id:            rec.ID.Hex(),
-		UserId:        rec.UserID,
-		Username:      rec.Username,
-		CheckinTime:   formatIST(rec.CheckinTime, s.loc),
-		StatusMessage: "User checked in successfully",
-	}, nil
+	checkIns.Inc()
+	return toResponse(&rec, loc, "User checked in successfully"), nil
 }
 
 func (s *attendanceServer) CheckOut(ctx context.Context, req *pb.CheckOutRequest) (*pb.AttendanceRecordResponse, error) {
-	log.Println("[CheckOut]", req)
 	if req.GetRecordId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "record_id required")
 	}
@@ -74,93 +228,190 @@ func (s *attendanceServer) CheckOut(ctx context.Context, req *pb.CheckOutRequest
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid record_id")
 	}
+	// Callers limited to some records get the same error for a record that
+	// does not exist as for one they may not touch, so ids cannot be probed.
+	denied := status.Errorf(codes.PermissionDenied, "record %s does not exist or may not be checked out by the caller", req.GetRecordId())
+	existing, err := s.store.Get(ctx, oid)
+	if err != nil {
+		if err == ErrNotFound {
+			if !s.policy.unrestricted(ctx) {
+				return nil, denied
+			}
+			return nil, status.Error(codes.NotFound, "record not found")
+		}
+		return nil, status.Errorf(codes.Internal, "db error: %v", err)
+	}
+	if s.policy.canActAs(ctx, existing.UserID) != nil || s.policy.canActAtSite(ctx, existing) != nil {
+		return nil, denied
+	}
+	loc, err := s.locationFor(ctx, req.GetTimeZone(), existing.UserID)
+	if err != nil {
+		return nil, err
+	}
 
-	now := time.Now().UTC()
-	update := bson.M{"$set": bson.M{"checkout_time": now}}
-	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-
-	var updated AttendanceRecord
-	err = s.collection.FindOneAndUpdate(ctx, bson.M{"_id": oid}, update, opts).Decode(&updated)
+	updated, err := s.store.CloseSession(ctx, oid, time.Now().UTC())
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
+		switch err {
+		case ErrNotFound:
 			return nil, status.Error(codes.NotFound, "record not found")
+		case ErrSessionClosed:
+			return nil, alreadyCheckedOut(updated, loc)
 		}
 		return nil, status.Errorf(codes.Internal, "update error: %v", err)
 	}
 
-	checkoutStr := ""
-	if updated.CheckoutTime != nil {
-		checkoutStr = formatIST(*updated.CheckoutTime, s.loc)
+	checkOuts.Inc()
+	return toResponse(updated, loc, "User checked out successfully"), nil
+}
+
+func (s *attendanceServer) CheckOutUser(ctx context.Context, req *pb.CheckOutUserRequest) (*pb.AttendanceRecordResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	if err := s.policy.canActAs(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	loc, err := s.locationFor(ctx, req.GetTimeZone(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.store.OpenByUser(ctx, req.GetUserId())
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "no open session for user")
+		}
+		return nil, status.Errorf(codes.Internal, "db error: %v", err)
+	}
+	if err := s.policy.canActAtSite(ctx, open); err != nil {
+		return nil, err
+	}
+
+	// Close the record that passed the site check, not whichever is open now.
+	updated, err := s.store.CloseSession(ctx, open.ID, time.Now().UTC())
+	if err != nil {
+		if err == ErrNotFound || err == ErrSessionClosed {
+			return nil, status.Error(codes.NotFound, "no open session for user")
+		}
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
 	}
 
-	return &pb.AttendanceRecordResponse{
-		Id:            updated.ID.Hex(),
-		UserId:        updated.UserID,
-		Username:      updated.Username,
-		CheckinTime:   formatIST(updated.CheckinTime, s.loc),
-		CheckoutTime:  checkoutStr,
-		StatusMessage: "User checked out successfully",
-	}, nil
+	checkOuts.Inc()
+	return toResponse(updated, loc, "User checked out successfully"), nil
 }
 
 func (s *attendanceServer) GetAttendance(ctx context.Context, req *pb.GetAttendanceRequest) (*pb.AttendanceRecordResponse, error) {
-	log.Println("[GetAttendance]", req)
 	if req.GetUserId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id required")
 	}
+	if err := s.policy.canRead(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	loc, err := s.locationFor(ctx, req.GetTimeZone(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
 
-	filter := bson.M{"user_id": req.GetUserId()}
-	opts := options.FindOne().SetSort(bson.D{{Key: "checkin_time", Value: -1}})
-	var r AttendanceRecord
-	if err := s.collection.FindOne(ctx, filter, opts).Decode(&r); err != nil {
-		if err == mongo.ErrNoDocuments {
+	r, err := s.store.LatestByUser(ctx, req.GetUserId())
+	if err != nil {
+		if err == ErrNotFound {
 			return nil, status.Error(codes.NotFound, "no records found")
 		}
 		return nil, status.Errorf(codes.Internal, "db error: %v", err)
 	}
 
-	checkoutStr := ""
-	if r.CheckoutTime != nil {
-		checkoutStr = formatIST(*r.CheckoutTime, s.loc)
-	}
-
-	return &pb.AttendanceRecordResponse{
-		Id:            r.ID.Hex(),
-		UserId:        r.UserID,
-		Username:      r.Username,
-		CheckinTime:   formatIST(r.CheckinTime, s.loc),
-		CheckoutTime:  checkoutStr,
-		StatusMessage: "Record found",
-	}, nil
+	return toResponse(r, loc, "Record found"), nil
 }
 
 func (s *attendanceServer) GetAllAttendance(ctx context.Context, req *pb.GetAllAttendanceRequest) (*pb.GetAllAttendanceResponse, error) {
-	log.Println("[GetAllAttendance] request received")
-	cursor, err := s.collection.Find(ctx, bson.D{})
+	if err := s.policy.requireAdmin(ctx, "GetAllAttendance"); err != nil {
+		return nil, err
+	}
+	loc, err := s.location(req.GetTimeZone())
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.store.List(ctx, RecordQuery{})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "find error: %v", err)
 	}
-	defer cursor.Close(ctx)
 
 	var records []*pb.AttendanceRecordResponse
-	for cursor.Next(ctx) {
-		var r AttendanceRecord
-		if err := cursor.Decode(&r); err != nil {
-			continue
-		}
-		checkoutStr := ""
-		if r.CheckoutTime != nil {
-			checkoutStr = formatIST(*r.CheckoutTime, s.loc)
-		}
-		records = append(records, &pb.AttendanceRecordResponse{
-			Id:            r.ID.Hex(),
-			UserId:        r.UserID,
-			Username:      r.Username,
-			CheckinTime:   formatIST(r.CheckinTime, s.loc),
-			CheckoutTime:  checkoutStr,
-			StatusMessage: "Record retrieved",
-		})
+	for i := range all {
+		records = append(records, toResponse(&all[i], loc, "Record retrieved"))
 	}
 
 	return &pb.GetAllAttendanceResponse{Records: records}, nil
 }
+
+func (s *attendanceServer) ListAttendance(ctx context.Context, req *pb.ListAttendanceRequest) (*pb.ListAttendanceResponse, error) {
+	if err := s.canList(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	loc, err := s.locationFor(ctx, req.GetTimeZone(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	q, err := listQuery(req, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.store.Count(ctx, q.RecordFilter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "count error: %v", err)
+	}
+
+	// Fetch one extra record to learn whether there is another page.
+	pageSize := q.Limit
+	q.Limit++
+	page, err := s.store.List(ctx, q)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "find error: %v", err)
+	}
+
+	resp := &pb.ListAttendanceResponse{TotalCount: total}
+	if len(page) > pageSize {
+		page = page[:pageSize]
+		last := page[len(page)-1]
+		resp.NextPageToken = encodePageToken(RecordCursor{CheckinTime: last.CheckinTime, ID: last.ID}, q)
+	}
+	for i := range page {
+		resp.Records = append(resp.Records, toResponse(&page[i], loc, "Record retrieved"))
+	}
+	return resp, nil
+}
+
+func (s *attendanceServer) StreamAttendance(req *pb.StreamAttendanceRequest, stream pb.AttendanceService_StreamAttendanceServer) error {
+	ctx := stream.Context()
+	if err := s.canList(ctx, req.GetUserId()); err != nil {
+		return err
+	}
+	loc, err := s.locationFor(ctx, req.GetTimeZone(), req.GetUserId())
+	if err != nil {
+		return err
+	}
+	f, err := recordFilter(loc, req.GetUserId(), req.GetStartDate(), req.GetEndDate(), req.GetStatus())
+	if err != nil {
+		return err
+	}
+	q := RecordQuery{
+		RecordFilter: f,
+		Ascending:    req.GetSort() == pb.SortOrder_SORT_ORDER_OLDEST_FIRST,
+	}
+
+	err = s.store.Each(ctx, q, func(r *AttendanceRecord) error {
+		return stream.Send(toResponse(r, loc, "Record retrieved"))
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "stream error: %v", err)
+	}
+	return nil
+}
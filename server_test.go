@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestServer returns an attendanceServer on in-memory stores with the
+// given users registered. Authentication is off, so every call is allowed.
+func newTestServer(t *testing.T, userIDs ...string) *attendanceServer {
+	t.Helper()
+	users := newMemoryUserStore()
+	for _, id := range userIDs {
+		if err := users.Create(context.Background(), &User{UserID: id, Username: "name-" + id, Active: true}); err != nil {
+			t.Fatalf("create user %s: %v", id, err)
+		}
+	}
+	return &attendanceServer{
+		store:  newMemoryStore(),
+		users:  users,
+		shifts: newMemoryShiftStore(),
+		policy: &accessPolicy{users: users},
+		loc:    time.UTC,
+	}
+}
+
+// wantCode fails the test unless err carries the gRPC code.
+func wantCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != code {
+		t.Fatalf("got code %v (%v), want %v", got, err, code)
+	}
+}
+
+func TestCheckInCheckOutGetAttendance(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "u1")
+
+	in, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1", Username: "ignored"})
+	if err != nil {
+		t.Fatalf("CheckIn: %v", err)
+	}
+	if in.GetUsername() != "name-u1" {
+		t.Errorf("username = %q, want the registry's name-u1", in.GetUsername())
+	}
+	if in.GetCheckoutAt() != nil {
+		t.Errorf("new record has checkout_at %v", in.GetCheckoutAt())
+	}
+
+	got, err := s.GetAttendance(ctx, &pb.GetAttendanceRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatalf("GetAttendance: %v", err)
+	}
+	if got.GetId() != in.GetId() {
+		t.Errorf("GetAttendance id = %s, want %s", got.GetId(), in.GetId())
+	}
+
+	out, err := s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: in.GetId()})
+	if err != nil {
+		t.Fatalf("CheckOut: %v", err)
+	}
+	if out.GetCheckoutAt() == nil {
+		t.Fatal("checked-out record has no checkout_at")
+	}
+	if out.GetCheckoutAt().AsTime().Before(out.GetCheckinAt().AsTime()) {
+		t.Errorf("checkout %v before checkin %v", out.GetCheckoutAt().AsTime(), out.GetCheckinAt().AsTime())
+	}
+}
+
+func TestCheckInValidation(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "u1", "gone")
+	inactive := false
+	if _, err := s.users.Update(ctx, "gone", UserUpdate{Active: &inactive}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.CheckInRequest
+		code codes.Code
+	}{
+		{"missing user_id", &pb.CheckInRequest{}, codes.InvalidArgument},
+		{"unregistered", &pb.CheckInRequest{UserId: "nobody"}, codes.NotFound},
+		{"deactivated", &pb.CheckInRequest{UserId: "gone"}, codes.FailedPrecondition},
+		{"bad time zone", &pb.CheckInRequest{UserId: "u1", TimeZone: "Mars/Base"}, codes.InvalidArgument},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.CheckIn(ctx, tc.req)
+			wantCode(t, err, tc.code)
+		})
+	}
+}
+
+func TestGetAttendanceErrors(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "u1")
+
+	_, err := s.GetAttendance(ctx, &pb.GetAttendanceRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = s.GetAttendance(ctx, &pb.GetAttendanceRequest{UserId: "u1"})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: "not-hex"})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: "000000000000000000000000"})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestCheckInTwiceIsAlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "u1")
+	first, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"})
+	wantCode(t, err, codes.AlreadyExists)
+	var openID string
+	for _, d := range status.Convert(err).Details() {
+		if ri, ok := d.(*errdetails.ResourceInfo); ok {
+			openID = ri.GetResourceName()
+		}
+	}
+	if openID != first.GetId() {
+		t.Errorf("error details name record %q, want the open record %q", openID, first.GetId())
+	}
+
+	// Checking out frees the user to check in again.
+	if _, err := s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: first.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"}); err != nil {
+		t.Errorf("CheckIn after checkout: %v", err)
+	}
+}
+
+func TestCheckOutTwiceIsFailedPrecondition(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "u1")
+	in, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: in.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: in.GetId()})
+	wantCode(t, err, codes.FailedPrecondition)
+	var violation *errdetails.PreconditionFailure_Violation
+	for _, d := range status.Convert(err).Details() {
+		if pf, ok := d.(*errdetails.PreconditionFailure); ok && len(pf.GetViolations()) > 0 {
+			violation = pf.GetViolations()[0]
+		}
+	}
+	if violation == nil || violation.GetType() != "ALREADY_CHECKED_OUT" || violation.GetSubject() != in.GetId() {
+		t.Errorf("violation = %v, want ALREADY_CHECKED_OUT for %s", violation, in.GetId())
+	}
+
+	got, err := s.GetAttendance(ctx, &pb.GetAttendanceRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.GetCheckoutAt().AsTime().Equal(out.GetCheckoutAt().AsTime()) {
+		t.Errorf("checkout_at changed from %v to %v", out.GetCheckoutAt().AsTime(), got.GetCheckoutAt().AsTime())
+	}
+}
+
+func TestCheckOutUser(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "u1", "u2")
+
+	_, err := s.CheckOutUser(ctx, &pb.CheckOutUserRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = s.CheckOutUser(ctx, &pb.CheckOutUserRequest{UserId: "u1"})
+	wantCode(t, err, codes.NotFound)
+
+	in, err := s.CheckIn(kioskContext("A"), &pb.CheckInRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u2"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.CheckOutUser(kioskContext("B"), &pb.CheckOutUserRequest{UserId: "u1"})
+	wantCode(t, err, codes.PermissionDenied)
+
+	out, err := s.CheckOutUser(kioskContext("A"), &pb.CheckOutUserRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatalf("CheckOutUser: %v", err)
+	}
+	if out.GetId() != in.GetId() || out.GetCheckoutAt() == nil {
+		t.Errorf("closed %s (checkout_at %v), want %s closed", out.GetId(), out.GetCheckoutAt(), in.GetId())
+	}
+	_, err = s.CheckOutUser(kioskContext("A"), &pb.CheckOutUserRequest{UserId: "u1"})
+	wantCode(t, err, codes.NotFound)
+
+	// Other users' sessions are untouched.
+	if open, err := s.store.OpenByUser(ctx, "u2"); err != nil || open.CheckoutTime != nil {
+		t.Errorf("u2 open session = %v, %v; want still open", open, err)
+	}
+}
+
+// fakeStream collects what StreamAttendance sends, calling onSend after each.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	sent   []*pb.AttendanceRecordResponse
+	onSend func()
+}
+
+func (f *fakeStream) Context() context.Context { return f.ctx }
+
+func (f *fakeStream) Send(r *pb.AttendanceRecordResponse) error {
+	f.sent = append(f.sent, r)
+	if f.onSend != nil {
+		f.onSend()
+	}
+	return nil
+}
+
+func TestStreamAttendance(t *testing.T) {
+	s := newTestServer(t, "u1", "u2")
+	m := s.store.(*memoryStore)
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+	mon := insertRecord(t, m, "u1", day(2, 9), day(2, 17)).ID.Hex()
+	tue := insertRecord(t, m, "u1", day(3, 9), day(3, 17)).ID.Hex()
+	wed := insertRecord(t, m, "u1", day(4, 9), time.Time{}).ID.Hex()
+	other := insertRecord(t, m, "u2", day(3, 10), day(3, 12)).ID.Hex()
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.StreamAttendanceRequest
+		want []string
+	}{
+		{"everything, newest first", &pb.StreamAttendanceRequest{}, []string{wed, other, tue, mon}},
+		{"oldest first", &pb.StreamAttendanceRequest{Sort: pb.SortOrder_SORT_ORDER_OLDEST_FIRST}, []string{mon, tue, other, wed}},
+		{"user from a date", &pb.StreamAttendanceRequest{UserId: "u1", StartDate: "2026-03-03"}, []string{wed, tue}},
+		{"end date is inclusive", &pb.StreamAttendanceRequest{EndDate: "2026-03-03"}, []string{other, tue, mon}},
+		{"open", &pb.StreamAttendanceRequest{Status: pb.SessionStatus_SESSION_STATUS_OPEN}, []string{wed}},
+		{"closed, oldest first", &pb.StreamAttendanceRequest{UserId: "u1", Status: pb.SessionStatus_SESSION_STATUS_CLOSED, Sort: pb.SortOrder_SORT_ORDER_OLDEST_FIRST}, []string{mon, tue}},
+		{"empty range", &pb.StreamAttendanceRequest{StartDate: "2026-03-05"}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stream := &fakeStream{ctx: context.Background()}
+			if err := s.StreamAttendance(tc.req, stream); err != nil {
+				t.Fatalf("StreamAttendance: %v", err)
+			}
+			var got []string
+			for _, r := range stream.sent {
+				got = append(got, r.GetId())
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("record %d = %s, want %s", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+
+	err := s.StreamAttendance(&pb.StreamAttendanceRequest{StartDate: "03/03/2026"}, &fakeStream{ctx: context.Background()})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestStreamAttendanceCancelled(t *testing.T) {
+	s := newTestServer(t, "u1")
+	m := s.store.(*memoryStore)
+	for i := 0; i < 5; i++ {
+		in := t0.Add(time.Duration(i) * 24 * time.Hour)
+		insertRecord(t, m, "u1", in, in.Add(time.Hour))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &fakeStream{ctx: ctx, onSend: cancel} // the client goes away after one record
+	err := s.StreamAttendance(&pb.StreamAttendanceRequest{UserId: "u1"}, stream)
+	wantCode(t, err, codes.Canceled)
+	if len(stream.sent) != 1 {
+		t.Errorf("sent %d records after the client went away, want 1", len(stream.sent))
+	}
+}
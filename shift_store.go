@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrShiftExists is returned by ShiftStore.Create for a duplicate shift_id.
+var ErrShiftExists = errors.New("shift already exists")
+
+// Mongo Model. Times are minutes after midnight in the shift's time zone; an
+// end at or before the start means the shift ends the next day.
+type Shift struct {
+	ShiftID     string         `bson:"_id"`
+	Name        string         `bson:"name"`
+	StartMinute int            `bson:"start_minute"`
+	EndMinute   int            `bson:"end_minute"`
+	Grace       time.Duration  `bson:"grace"`
+	Days        []time.Weekday `bson:"days,omitempty"` // start days; empty means every day
+	TimeZone    string         `bson:"time_zone,omitempty"`
+	CreatedAt   time.Time      `bson:"created_at"`
+	UpdatedAt   time.Time      `bson:"updated_at"`
+}
+
+// ShiftStore is the persistence layer behind shift definitions.
+type ShiftStore interface {
+	// Create stores a new shift, failing with ErrShiftExists on a duplicate id.
+	Create(ctx context.Context, sh *Shift) error
+	// Get returns the shift or ErrNotFound.
+	Get(ctx context.Context, shiftID string) (*Shift, error)
+	// Update replaces every field but CreatedAt and returns the stored shift,
+	// or ErrNotFound.
+	Update(ctx context.Context, sh *Shift) (*Shift, error)
+	// Delete removes the shift or returns ErrNotFound.
+	Delete(ctx context.Context, shiftID string) error
+	// List returns every shift ordered by id.
+	List(ctx context.Context) ([]Shift, error)
+}
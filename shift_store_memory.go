@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// memoryShiftStore keeps shift definitions in process memory.
+type memoryShiftStore struct {
+	mu     sync.RWMutex
+	shifts map[string]Shift
+}
+
+func newMemoryShiftStore() *memoryShiftStore {
+	return &memoryShiftStore{shifts: map[string]Shift{}}
+}
+
+func (m *memoryShiftStore) Create(ctx context.Context, sh *Shift) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.shifts[sh.ShiftID]; ok {
+		return ErrShiftExists
+	}
+	m.shifts[sh.ShiftID] = *sh
+	return nil
+}
+
+func (m *memoryShiftStore) Get(ctx context.Context, shiftID string) (*Shift, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	sh, ok := m.shifts[shiftID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &sh, nil
+}
+
+func (m *memoryShiftStore) Update(ctx context.Context, sh *Shift) (*Shift, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	old, ok := m.shifts[sh.ShiftID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	out := *sh
+	out.CreatedAt = old.CreatedAt
+	m.shifts[sh.ShiftID] = out
+	return &out, nil
+}
+
+func (m *memoryShiftStore) Delete(ctx context.Context, shiftID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.shifts[shiftID]; !ok {
+		return ErrNotFound
+	}
+	delete(m.shifts, shiftID)
+	return nil
+}
+
+func (m *memoryShiftStore) List(ctx context.Context) ([]Shift, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	shifts := make([]Shift, 0, len(m.shifts))
+	for _, sh := range m.shifts {
+		shifts = append(shifts, sh)
+	}
+	sort.Slice(shifts, func(i, j int) bool { return shifts[i].ShiftID < shifts[j].ShiftID })
+	return shifts, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoShiftStore keeps shift definitions in a MongoDB collection keyed by
+// shift_id.
+type mongoShiftStore struct {
+	collection *mongo.Collection
+}
+
+func newMongoShiftStore(collection *mongo.Collection) *mongoShiftStore {
+	return &mongoShiftStore{collection: collection}
+}
+
+func (m *mongoShiftStore) Create(ctx context.Context, sh *Shift) error {
+	_, err := m.collection.InsertOne(ctx, sh)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrShiftExists
+	}
+	return err
+}
+
+func (m *mongoShiftStore) Get(ctx context.Context, shiftID string) (*Shift, error) {
+	var sh Shift
+	err := m.collection.FindOne(ctx, bson.M{"_id": shiftID}).Decode(&sh)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sh, nil
+}
+
+func (m *mongoShiftStore) Update(ctx context.Context, sh *Shift) (*Shift, error) {
+	set := bson.M{
+		"name":         sh.Name,
+		"start_minute": sh.StartMinute,
+		"end_minute":   sh.EndMinute,
+		"grace":        sh.Grace,
+		"days":         sh.Days,
+		"time_zone":    sh.TimeZone,
+		"updated_at":   sh.UpdatedAt,
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var out Shift
+	err := m.collection.FindOneAndUpdate(ctx, bson.M{"_id": sh.ShiftID}, bson.M{"$set": set}, opts).Decode(&out)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (m *mongoShiftStore) Delete(ctx context.Context, shiftID string) error {
+	res, err := m.collection.DeleteOne(ctx, bson.M{"_id": shiftID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (m *mongoShiftStore) List(ctx context.Context) ([]Shift, error) {
+	cursor, err := m.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var shifts []Shift
+	if err := cursor.All(ctx, &shifts); err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
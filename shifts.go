@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// shiftEarlyWindow is how long before a shift starts a check-in still counts
+// towards it; earlier check-ins are off shift.
+const shiftEarlyWindow = 4 * time.Hour
+
+// ShiftOccurrence is the shift instance a session was matched to at
+// check-in, stored on the record so later edits to the shift do not rewrite
+// history.
+type ShiftOccurrence struct {
+	ShiftID string        `bson:"shift_id"`
+	Start   time.Time     `bson:"start,omitempty"` // zero when checked in off shift
+	End     time.Time     `bson:"end,omitempty"`
+	Grace   time.Duration `bson:"grace,omitempty"`
+}
+
+// Overnight reports whether the shift ends on the day after it starts.
+func (sh *Shift) Overnight() bool {
+	return sh.EndMinute <= sh.StartMinute
+}
+
+func (sh *Shift) runsOn(d time.Weekday) bool {
+	if len(sh.Days) == 0 {
+		return true
+	}
+	for _, day := range sh.Days {
+		if day == d {
+			return true
+		}
+	}
+	return false
+}
+
+// location returns the shift's zone, or def when it has none.
+func (sh *Shift) location(def *time.Location) *time.Location {
+	if sh.TimeZone == "" {
+		return def
+	}
+	if loc, err := loadZone(sh.TimeZone); err == nil {
+		return loc
+	}
+	return def
+}
+
+// occurrence matches a check-in at t to the shift instance starting nearest
+// to it among those that have not ended and start at most shiftEarlyWindow
+// after t. Without one, the check-in is off shift.
+func (sh *Shift) occurrence(t time.Time, loc *time.Location) *ShiftOccurrence {
+	lt := t.In(loc)
+	var best *ShiftOccurrence
+	for _, offset := range []int{-1, 0, 1} {
+		day := time.Date(lt.Year(), lt.Month(), lt.Day()+offset, 0, 0, 0, 0, loc)
+		if !sh.runsOn(day.Weekday()) {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, sh.StartMinute, 0, 0, loc)
+		endDay := day.Day()
+		if sh.Overnight() {
+			endDay++
+		}
+		end := time.Date(day.Year(), day.Month(), endDay, 0, sh.EndMinute, 0, 0, loc)
+		if t.Before(start.Add(-shiftEarlyWindow)) || !t.Before(end) {
+			continue
+		}
+		if best == nil || absDuration(t.Sub(start)) < absDuration(t.Sub(best.Start)) {
+			best = &ShiftOccurrence{ShiftID: sh.ShiftID, Start: start.UTC(), End: end.UTC(), Grace: sh.Grace}
+		}
+	}
+	if best == nil {
+		return &ShiftOccurrence{ShiftID: sh.ShiftID}
+	}
+	return best
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// punctuality compares a session with the shift occurrence. Lateness and
+// early leaving within the grace period are ignored; beyond it the full
+// difference is reported.
+func (o *ShiftOccurrence) punctuality(checkin time.Time, checkout *time.Time) (st pb.ShiftStatus, lateBy, earlyBy time.Duration) {
+	if o.Start.IsZero() {
+		return pb.ShiftStatus_SHIFT_STATUS_OFF_SHIFT, 0, 0
+	}
+	if d := checkin.Sub(o.Start); d > o.Grace {
+		lateBy = d
+	}
+	if checkout != nil {
+		if d := o.End.Sub(*checkout); d > o.Grace {
+			earlyBy = d
+		}
+	}
+	switch {
+	case lateBy > 0 && earlyBy > 0:
+		return pb.ShiftStatus_SHIFT_STATUS_LATE_AND_LEFT_EARLY, lateBy, earlyBy
+	case lateBy > 0:
+		return pb.ShiftStatus_SHIFT_STATUS_LATE, lateBy, 0
+	case earlyBy > 0:
+		return pb.ShiftStatus_SHIFT_STATUS_LEFT_EARLY, 0, earlyBy
+	}
+	return pb.ShiftStatus_SHIFT_STATUS_ON_TIME, 0, 0
+}
+
+// gRPC server struct for shift definitions and assignment
+type shiftServer struct {
+	pb.UnimplementedShiftServiceServer
+	shifts ShiftStore
+	users  UserStore
+	policy *accessPolicy
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func parseClock(name, v string) (int, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be HH:MM, got %q", name, v)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func toShiftResponse(sh *Shift) *pb.Shift {
+	resp := &pb.Shift{
+		ShiftId:     sh.ShiftID,
+		Name:        sh.Name,
+		StartTime:   formatClock(sh.StartMinute),
+		EndTime:     formatClock(sh.EndMinute),
+		GracePeriod: durationpb.New(sh.Grace),
+		TimeZone:    sh.TimeZone,
+		Overnight:   sh.Overnight(),
+		CreatedAt:   timestamppb.New(sh.CreatedAt),
+		UpdatedAt:   timestamppb.New(sh.UpdatedAt),
+	}
+	for _, d := range sh.Days {
+		// DAY_OF_WEEK_MONDAY is 1 like time.Monday; Sunday is 7, not 0.
+		if d == time.Sunday {
+			resp.DaysOfWeek = append(resp.DaysOfWeek, pb.DayOfWeek_DAY_OF_WEEK_SUNDAY)
+		} else {
+			resp.DaysOfWeek = append(resp.DaysOfWeek, pb.DayOfWeek(d))
+		}
+	}
+	return resp
+}
+
+// shiftFromRequest validates a shift definition.
+func shiftFromRequest(req *pb.Shift) (*Shift, error) {
+	if req.GetShiftId() == "" || req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "shift_id and name required")
+	}
+	start, err := parseClock("start_time", req.GetStartTime())
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock("end_time", req.GetEndTime())
+	if err != nil {
+		return nil, err
+	}
+	if err := validTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+	sh := &Shift{
+		ShiftID:     req.GetShiftId(),
+		Name:        req.GetName(),
+		StartMinute: start,
+		EndMinute:   end,
+		Grace:       req.GetGracePeriod().AsDuration(),
+		TimeZone:    req.GetTimeZone(),
+	}
+	length := time.Duration(end-start) * time.Minute
+	if sh.Overnight() {
+		length += 24 * time.Hour
+	}
+	if sh.Grace < 0 || sh.Grace >= length {
+		return nil, status.Errorf(codes.InvalidArgument, "grace_period must be between 0 and the shift length (%s)", length)
+	}
+
+	seen := map[time.Weekday]bool{}
+	for _, d := range req.GetDaysOfWeek() {
+		if d < pb.DayOfWeek_DAY_OF_WEEK_MONDAY || d > pb.DayOfWeek_DAY_OF_WEEK_SUNDAY {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid day_of_week %v", d)
+		}
+		wd := time.Weekday(d % 7)
+		if !seen[wd] {
+			seen[wd] = true
+			sh.Days = append(sh.Days, wd)
+		}
+	}
+	return sh, nil
+}
+
+// --- gRPC Methods ---
+func (s *shiftServer) CreateShift(ctx context.Context, req *pb.Shift) (*pb.Shift, error) {
+	if err := s.policy.requireAdmin(ctx, "CreateShift"); err != nil {
+		return nil, err
+	}
+	sh, err := shiftFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	sh.CreatedAt = time.Now().UTC()
+	sh.UpdatedAt = sh.CreatedAt
+	if err := s.shifts.Create(ctx, sh); err != nil {
+		if err == ErrShiftExists {
+			return nil, status.Error(codes.AlreadyExists, "shift already exists")
+		}
+		return nil, status.Errorf(codes.Internal, "insert error: %v", err)
+	}
+	return toShiftResponse(sh), nil
+}
+
+func (s *shiftServer) GetShift(ctx context.Context, req *pb.GetShiftRequest) (*pb.Shift, error) {
+	if req.GetShiftId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "shift_id required")
+	}
+	sh, err := s.shifts.Get(ctx, req.GetShiftId())
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "shift not found")
+		}
+		return nil, status.Errorf(codes.Internal, "db error: %v", err)
+	}
+	return toShiftResponse(sh), nil
+}
+
+func (s *shiftServer) UpdateShift(ctx context.Context, req *pb.Shift) (*pb.Shift, error) {
+	if err := s.policy.requireAdmin(ctx, "UpdateShift"); err != nil {
+		return nil, err
+	}
+	sh, err := shiftFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	sh.UpdatedAt = time.Now().UTC()
+	updated, err := s.shifts.Update(ctx, sh)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "shift not found")
+		}
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	}
+	return toShiftResponse(updated), nil
+}
+
+func (s *shiftServer) DeleteShift(ctx context.Context, req *pb.DeleteShiftRequest) (*pb.DeleteShiftResponse, error) {
+	if err := s.policy.requireAdmin(ctx, "DeleteShift"); err != nil {
+		return nil, err
+	}
+	if req.GetShiftId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "shift_id required")
+	}
+	// Checked first so users left on an already deleted shift do not make
+	// it look assigned.
+	if _, err := s.shifts.Get(ctx, req.GetShiftId()); err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "shift not found")
+		}
+		return nil, status.Errorf(codes.Internal, "db error: %v", err)
+	}
+	assigned, err := s.users.List(ctx, UserQuery{IncludeInactive: true, ShiftID: req.GetShiftId(), Limit: 1})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "find error: %v", err)
+	}
+	if len(assigned) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "shift is still assigned to users (e.g. %q)", assigned[0].UserID)
+	}
+	if err := s.shifts.Delete(ctx, req.GetShiftId()); err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "shift not found")
+		}
+		return nil, status.Errorf(codes.Internal, "delete error: %v", err)
+	}
+	return &pb.DeleteShiftResponse{}, nil
+}
+
+func (s *shiftServer) ListShifts(ctx context.Context, req *pb.ListShiftsRequest) (*pb.ListShiftsResponse, error) {
+	shifts, err := s.shifts.List(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "find error: %v", err)
+	}
+	resp := &pb.ListShiftsResponse{}
+	for i := range shifts {
+		resp.Shifts = append(resp.Shifts, toShiftResponse(&shifts[i]))
+	}
+	return resp, nil
+}
+
+func (s *shiftServer) AssignShift(ctx context.Context, req *pb.AssignShiftRequest) (*pb.User, error) {
+	if err := s.policy.requireAdmin(ctx, "AssignShift"); err != nil {
+		return nil, err
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	shiftID := req.GetShiftId()
+	if shiftID != "" {
+		if _, err := s.shifts.Get(ctx, shiftID); err != nil {
+			if err == ErrNotFound {
+				return nil, status.Error(codes.NotFound, "shift not found")
+			}
+			return nil, status.Errorf(codes.Internal, "db error: %v", err)
+		}
+	}
+	u, err := s.users.Update(ctx, req.GetUserId(), UserUpdate{ShiftID: &shiftID}, time.Now().UTC())
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	}
+	return toUserResponse(u), nil
+}
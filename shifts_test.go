@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestShiftOccurrence(t *testing.T) {
+	kolkata, err := loadZone("Asia/Kolkata")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	// t0 is Monday 2 March 2026, 09:00 UTC.
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	at := func(d, h, m int) time.Time {
+		return day.AddDate(0, 0, d).Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	dayShift := &Shift{ShiftID: "day", StartMinute: 9 * 60, EndMinute: 17 * 60}
+	night := &Shift{ShiftID: "night", StartMinute: 22 * 60, EndMinute: 6 * 60}
+	weeknights := &Shift{ShiftID: "weeknights", StartMinute: 22 * 60, EndMinute: 6 * 60, Days: weekdays}
+	long := &Shift{ShiftID: "long", StartMinute: 20 * 60, EndMinute: 19 * 60} // 23 hours
+
+	for _, tc := range []struct {
+		name       string
+		sh         *Shift
+		t          time.Time
+		loc        *time.Location
+		start, end time.Time // zero when off shift
+	}{
+		{"a little early", dayShift, at(0, 8, 50), time.UTC, at(0, 9, 0), at(0, 17, 0)},
+		{"start of early window", dayShift, at(0, 5, 0), time.UTC, at(0, 9, 0), at(0, 17, 0)},
+		{"before early window", dayShift, at(0, 4, 59), time.UTC, time.Time{}, time.Time{}},
+		{"late", dayShift, at(0, 16, 59), time.UTC, at(0, 9, 0), at(0, 17, 0)},
+		{"at shift end", dayShift, at(0, 17, 0), time.UTC, time.Time{}, time.Time{}},
+		{"overnight after midnight", night, at(1, 1, 0), time.UTC, at(0, 22, 0), at(1, 6, 0)},
+		{"overnight before start", night, at(0, 20, 0), time.UTC, at(0, 22, 0), at(1, 6, 0)},
+		{"overnight from previous day", night, at(0, 3, 0), time.UTC, at(-1, 22, 0), at(0, 6, 0)},
+		{"overnight from Friday", weeknights, at(5, 1, 0), time.UTC, at(4, 22, 0), at(5, 6, 0)},
+		{"no shift starts Saturday", weeknights, at(6, 1, 0), time.UTC, time.Time{}, time.Time{}},
+		{"no shift starts Sunday", weeknights, at(6, 21, 0), time.UTC, time.Time{}, time.Time{}},
+		{"Monday after the weekend", weeknights, at(7, 20, 0), time.UTC, at(7, 22, 0), at(8, 6, 0)},
+		{"nearest of two", long, at(0, 18, 0), time.UTC, at(0, 20, 0), at(1, 19, 0)},
+		{"shift zone", dayShift, at(0, 3, 35), kolkata, at(0, 3, 30), at(0, 11, 30)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o := tc.sh.occurrence(tc.t, tc.loc)
+			if o.ShiftID != tc.sh.ShiftID {
+				t.Errorf("shift id = %q, want %q", o.ShiftID, tc.sh.ShiftID)
+			}
+			if !o.Start.Equal(tc.start) || !o.End.Equal(tc.end) {
+				t.Errorf("occurrence = %v to %v, want %v to %v", o.Start, o.End, tc.start, tc.end)
+			}
+		})
+	}
+}
+
+func TestShiftPunctuality(t *testing.T) {
+	o := &ShiftOccurrence{ShiftID: "day", Start: t0, End: t0.Add(8 * time.Hour), Grace: 10 * time.Minute}
+	out := func(d time.Duration) *time.Time { t := t0.Add(d); return &t }
+
+	for _, tc := range []struct {
+		name          string
+		occ           *ShiftOccurrence
+		in            time.Time
+		out           *time.Time
+		status        pb.ShiftStatus
+		late, earlyBy time.Duration
+	}{
+		{"on time, still in", o, t0.Add(5 * time.Minute), nil, pb.ShiftStatus_SHIFT_STATUS_ON_TIME, 0, 0},
+		{"late within grace", o, t0.Add(10 * time.Minute), nil, pb.ShiftStatus_SHIFT_STATUS_ON_TIME, 0, 0},
+		{"late beyond grace", o, t0.Add(11 * time.Minute), nil, pb.ShiftStatus_SHIFT_STATUS_LATE, 11 * time.Minute, 0},
+		{"left early", o, t0, out(7*time.Hour + 45*time.Minute), pb.ShiftStatus_SHIFT_STATUS_LEFT_EARLY, 0, 15 * time.Minute},
+		{"left within grace", o, t0, out(7*time.Hour + 50*time.Minute), pb.ShiftStatus_SHIFT_STATUS_ON_TIME, 0, 0},
+		{"late and left early", o, t0.Add(30 * time.Minute), out(7 * time.Hour), pb.ShiftStatus_SHIFT_STATUS_LATE_AND_LEFT_EARLY, 30 * time.Minute, time.Hour},
+		{"early in, late out", o, t0.Add(-time.Hour), out(9 * time.Hour), pb.ShiftStatus_SHIFT_STATUS_ON_TIME, 0, 0},
+		{"off shift", &ShiftOccurrence{ShiftID: "day"}, t0.Add(3 * time.Hour), nil, pb.ShiftStatus_SHIFT_STATUS_OFF_SHIFT, 0, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			st, late, early := tc.occ.punctuality(tc.in, tc.out)
+			if st != tc.status || late != tc.late || early != tc.earlyBy {
+				t.Errorf("punctuality = %v, late %v, early %v; want %v, %v, %v", st, late, early, tc.status, tc.late, tc.earlyBy)
+			}
+		})
+	}
+}
+
+func TestShiftFromRequest(t *testing.T) {
+	valid := func(edit func(*pb.Shift)) *pb.Shift {
+		req := &pb.Shift{ShiftId: "day", Name: "Day", StartTime: "09:00", EndTime: "17:00", GracePeriod: durationpb.New(10 * time.Minute)}
+		if edit != nil {
+			edit(req)
+		}
+		return req
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.Shift
+	}{
+		{"missing shift_id", valid(func(r *pb.Shift) { r.ShiftId = "" })},
+		{"missing name", valid(func(r *pb.Shift) { r.Name = "" })},
+		{"bad start", valid(func(r *pb.Shift) { r.StartTime = "9am" })},
+		{"hour 24", valid(func(r *pb.Shift) { r.EndTime = "24:00" })},
+		{"bad zone", valid(func(r *pb.Shift) { r.TimeZone = "Mars/Base" })},
+		{"negative grace", valid(func(r *pb.Shift) { r.GracePeriod = durationpb.New(-time.Minute) })},
+		{"grace as long as the shift", valid(func(r *pb.Shift) { r.GracePeriod = durationpb.New(8 * time.Hour) })},
+		{"unspecified day", valid(func(r *pb.Shift) { r.DaysOfWeek = []pb.DayOfWeek{pb.DayOfWeek_DAY_OF_WEEK_UNSPECIFIED} })},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := shiftFromRequest(tc.req)
+			wantCode(t, err, codes.InvalidArgument)
+		})
+	}
+
+	sh, err := shiftFromRequest(valid(func(r *pb.Shift) {
+		r.StartTime, r.EndTime = "22:00", "06:00"
+		r.GracePeriod = durationpb.New(7 * time.Hour) // fits the 8 hour overnight shift
+		r.DaysOfWeek = []pb.DayOfWeek{pb.DayOfWeek_DAY_OF_WEEK_SUNDAY, pb.DayOfWeek_DAY_OF_WEEK_MONDAY, pb.DayOfWeek_DAY_OF_WEEK_SUNDAY}
+	}))
+	if err != nil {
+		t.Fatalf("overnight shift: %v", err)
+	}
+	if !sh.Overnight() || sh.StartMinute != 22*60 || sh.EndMinute != 6*60 {
+		t.Errorf("shift = %+v, want 22:00 to 06:00 overnight", sh)
+	}
+	if len(sh.Days) != 2 || sh.Days[0] != time.Sunday || sh.Days[1] != time.Monday {
+		t.Errorf("days = %v, want [Sunday Monday]", sh.Days)
+	}
+	if resp := toShiftResponse(sh); resp.GetStartTime() != "22:00" || resp.GetDaysOfWeek()[0] != pb.DayOfWeek_DAY_OF_WEEK_SUNDAY {
+		t.Errorf("response = %v, want start 22:00 and Sunday first", resp)
+	}
+}
+
+func TestShiftDeletedWhileAssigned(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "u1")
+	shifts := &shiftServer{shifts: s.shifts, users: s.users, policy: s.policy}
+	for _, id := range []string{"day", "late"} {
+		if err := s.shifts.Create(ctx, &Shift{ShiftID: id, Name: id, StartMinute: 9 * 60, EndMinute: 17 * 60}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// DeleteShift found nobody assigned, then AssignShift ran before the delete.
+	if _, err := shifts.AssignShift(ctx, &pb.AssignShiftRequest{UserId: "u1", ShiftId: "day"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.shifts.Delete(ctx, "day"); err != nil {
+		t.Fatal(err)
+	}
+
+	in, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatalf("CheckIn with a deleted shift: %v", err)
+	}
+	if in.GetShiftId() != "" || in.GetShiftStatus() != pb.ShiftStatus_SHIFT_STATUS_UNSPECIFIED {
+		t.Errorf("shift = %q %v, want none", in.GetShiftId(), in.GetShiftStatus())
+	}
+	if _, err := s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: in.GetId()}); err != nil {
+		t.Errorf("CheckOut: %v", err)
+	}
+	_, err = shifts.DeleteShift(ctx, &pb.DeleteShiftRequest{ShiftId: "day"})
+	wantCode(t, err, codes.NotFound)
+
+	u, err := shifts.AssignShift(ctx, &pb.AssignShiftRequest{UserId: "u1", ShiftId: "late"})
+	if err != nil || u.GetShiftId() != "late" {
+		t.Errorf("reassign = %v, %v; want shift late", u, err)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrNotFound is returned by a store when no record matches.
+var ErrNotFound = errors.New("record not found")
+
+// ErrOpenSession is returned by Insert when the user already has a record
+// without a checkout time.
+var ErrOpenSession = errors.New("user already has an open session")
+
+// ErrSessionClosed is returned by CloseSession when the record already has a
+// checkout time. The closed record is returned alongside it.
+var ErrSessionClosed = errors.New("session already closed")
+
+// SessionState selects records by whether they have been checked out.
+type SessionState int
+
+const (
+	AnySession SessionState = iota
+	OpenSession
+	ClosedSession
+)
+
+// RecordFilter narrows the records returned by AttendanceStore.List.
+// Zero values mean "no filter".
+type RecordFilter struct {
+	UserID string
+	From   time.Time // checkin_time >= From
+	To     time.Time // checkin_time < To
+	State  SessionState
+}
+
+// RecordCursor is a position in the (checkin_time, _id) ordering used for
+// paging through List results.
+type RecordCursor struct {
+	CheckinTime time.Time
+	ID          primitive.ObjectID
+}
+
+// RecordQuery is a filtered, ordered and optionally paged List request.
+type RecordQuery struct {
+	RecordFilter
+	Ascending bool          // oldest first; newest first otherwise
+	After     *RecordCursor // resume strictly after this position
+	Limit     int           // 0 means no limit
+}
+
+// DayTotal aggregates one user's sessions over one calendar day.
+type DayTotal struct {
+	UserID   string
+	Day      time.Time // midnight in the zone the totals were computed in
+	FirstIn  time.Time
+	LastOut  time.Time
+	Sessions int
+	Worked   time.Duration // excluding breaks
+	StillIn  bool          // a session that touches this day is still open
+}
+
+// AttendanceStore is the persistence layer behind attendanceServer.
+type AttendanceStore interface {
+	// Insert stores a new open record. rec.ID must already be set. It fails
+	// with ErrOpenSession if the user already has an open record.
+	Insert(ctx context.Context, rec *AttendanceRecord) error
+	// Get returns the record with the id or ErrNotFound.
+	Get(ctx context.Context, id primitive.ObjectID) (*AttendanceRecord, error)
+	// CloseSession sets the checkout time of an open record and returns it.
+	// A record that is already closed is left untouched and returned with
+	// ErrSessionClosed. Like every close, it ends a running break at the
+	// checkout time.
+	CloseSession(ctx context.Context, id primitive.ObjectID, at time.Time) (*AttendanceRecord, error)
+	// AutoCloseSession closes an open record on the user's behalf, marking
+	// it auto-closed with the reason. It returns ErrSessionClosed if the
+	// record is no longer open.
+	AutoCloseSession(ctx context.Context, id primitive.ObjectID, at time.Time, reason string) (*AttendanceRecord, error)
+	// StartBreak appends a break starting at at to the user's open record
+	// and returns it. It fails with ErrNotFound without an open record,
+	// ErrOnBreak if a break is running, and ErrInvalidBreak if at is before
+	// check-in or the end of an earlier break.
+	StartBreak(ctx context.Context, userID string, at time.Time) (*AttendanceRecord, error)
+	// EndBreak ends the running break of the user's open record at at and
+	// returns the record, or fails with ErrNotFound, ErrNotOnBreak or
+	// ErrInvalidBreak (at before the break started).
+	EndBreak(ctx context.Context, userID string, at time.Time) (*AttendanceRecord, error)
+	// LatestByUser returns the user's record with the newest checkin time.
+	LatestByUser(ctx context.Context, userID string) (*AttendanceRecord, error)
+	// OpenByUser returns the user's record that has no checkout time.
+	OpenByUser(ctx context.Context, userID string) (*AttendanceRecord, error)
+	// List returns the records matching the query, ordered by checkin time
+	// and then id.
+	List(ctx context.Context, q RecordQuery) ([]AttendanceRecord, error)
+	// Each calls fn for every record matching the query, in List order,
+	// without loading them all into memory. It stops at the first error
+	// returned by fn or when ctx is done.
+	Each(ctx context.Context, q RecordQuery, fn func(*AttendanceRecord) error) error
+	// Count returns the number of records matching the filter.
+	Count(ctx context.Context, f RecordFilter) (int64, error)
+	// DailyTotals buckets the user's sessions overlapping [from, to) by
+	// calendar day in loc, splitting sessions at midnight. Open sessions
+	// and running breaks count up to now. Days without sessions are
+	// omitted. An empty userID returns totals for every user, ordered by
+	// day and then user.
+	DailyTotals(ctx context.Context, userID string, from, to time.Time, loc *time.Location, now time.Time) ([]DayTotal, error)
+}
+
+// closeDuplicateOpenSessions keeps only each user's newest open record open.
+// Older ones, left behind before one open session per user was enforced,
+// are auto-closed at the check-in of the next newer one. It returns how many
+// were closed.
+func closeDuplicateOpenSessions(ctx context.Context, store AttendanceStore) (int, error) {
+	type closing struct {
+		id primitive.ObjectID
+		at time.Time
+	}
+	var dups []closing
+	newer := map[string]time.Time{} // user -> check-in of the next newer open record
+	err := store.Each(ctx, RecordQuery{RecordFilter: RecordFilter{State: OpenSession}}, func(r *AttendanceRecord) error {
+		if at, ok := newer[r.UserID]; ok {
+			dups = append(dups, closing{r.ID, at})
+		}
+		newer[r.UserID] = r.CheckinTime
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, d := range dups {
+		rec, err := store.AutoCloseSession(ctx, d.id, d.at, closeReasonDuplicate)
+		if err == ErrSessionClosed {
+			continue
+		}
+		if err != nil {
+			return closed, err
+		}
+		closed++
+		slog.Warn("Closed duplicate open session", "record_id", rec.ID.Hex(), "user_id", rec.UserID, "checkout_at", d.at)
+	}
+	return closed, nil
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryStore keeps attendance records in process memory. It is meant for
+// local development and CI where no MongoDB is available.
+type memoryStore struct {
+	mu      sync.RWMutex
+	records []AttendanceRecord
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{}
+}
+
+func (m *memoryStore) Insert(ctx context.Context, rec *AttendanceRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.openByUser(rec.UserID) != nil {
+		return ErrOpenSession
+	}
+	m.records = append(m.records, *rec)
+	return nil
+}
+
+func (m *memoryStore) Get(ctx context.Context, id primitive.ObjectID) (*AttendanceRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for i := range m.records {
+		if m.records[i].ID == id {
+			r := m.records[i]
+			return &r, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *memoryStore) CloseSession(ctx context.Context, id primitive.ObjectID, at time.Time) (*AttendanceRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.records {
+		if m.records[i].ID == id {
+			if m.records[i].CheckoutTime != nil {
+				r := m.records[i]
+				return &r, ErrSessionClosed
+			}
+			t := at
+			m.records[i].CheckoutTime = &t
+			m.records[i].closeBreaks(at)
+			r := m.records[i]
+			return &r, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *memoryStore) AutoCloseSession(ctx context.Context, id primitive.ObjectID, at time.Time, reason string) (*AttendanceRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.records {
+		r := &m.records[i]
+		if r.ID != id || r.CheckoutTime != nil {
+			continue
+		}
+		t := at
+		r.CheckoutTime = &t
+		r.closeBreaks(at)
+		r.AutoClosed = true
+		r.CloseReason = reason
+		out := *r
+		return &out, nil
+	}
+	return nil, ErrSessionClosed
+}
+
+func (m *memoryStore) StartBreak(ctx context.Context, userID string, at time.Time) (*AttendanceRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	open := m.openByUser(userID)
+	if open == nil {
+		return nil, ErrNotFound
+	}
+	if err := checkBreakStart(open, at); err != nil {
+		return nil, err
+	}
+	// Copy on write: records handed out share the old slice.
+	open.Breaks = append(open.Breaks[:len(open.Breaks):len(open.Breaks)], Break{Start: at})
+	r := *open
+	return &r, nil
+}
+
+func (m *memoryStore) EndBreak(ctx context.Context, userID string, at time.Time) (*AttendanceRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	open := m.openByUser(userID)
+	if open == nil {
+		return nil, ErrNotFound
+	}
+	if err := checkBreakEnd(open, at); err != nil {
+		return nil, err
+	}
+	open.Breaks = append([]Break(nil), open.Breaks...) // copy on write
+	t := at
+	open.openBreak().End = &t
+	r := *open
+	return &r, nil
+}
+
+func (m *memoryStore) LatestByUser(ctx context.Context, userID string) (*AttendanceRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var latest *AttendanceRecord
+	for i := range m.records {
+		r := &m.records[i]
+		if r.UserID != userID {
+			continue
+		}
+		if latest == nil || r.CheckinTime.After(latest.CheckinTime) {
+			latest = r
+		}
+	}
+	if latest == nil {
+		return nil, ErrNotFound
+	}
+	r := *latest
+	return &r, nil
+}
+
+func (m *memoryStore) OpenByUser(ctx context.Context, userID string) (*AttendanceRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	open := m.openByUser(userID)
+	if open == nil {
+		return nil, ErrNotFound
+	}
+	r := *open
+	return &r, nil
+}
+
+// openByUser must be called with m.mu held.
+func (m *memoryStore) openByUser(userID string) *AttendanceRecord {
+	for i := range m.records {
+		if m.records[i].UserID == userID && m.records[i].CheckoutTime == nil {
+			return &m.records[i]
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) List(ctx context.Context, q RecordQuery) ([]AttendanceRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var records []AttendanceRecord
+	for _, r := range m.records {
+		if !matches(&r, q.RecordFilter) {
+			continue
+		}
+		if q.After != nil && !after(&r, q.After, q.Ascending) {
+			continue
+		}
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		a, b := &records[i], &records[j]
+		less := a.CheckinTime.Before(b.CheckinTime) ||
+			(a.CheckinTime.Equal(b.CheckinTime) && a.ID.Hex() < b.ID.Hex())
+		if q.Ascending {
+			return less
+		}
+		return !less && a.ID != b.ID
+	})
+	if q.Limit > 0 && len(records) > q.Limit {
+		records = records[:q.Limit]
+	}
+	return records, nil
+}
+
+func (m *memoryStore) Each(ctx context.Context, q RecordQuery, fn func(*AttendanceRecord) error) error {
+	records, err := m.List(ctx, q)
+	if err != nil {
+		return err
+	}
+	for i := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(&records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) Count(ctx context.Context, f RecordFilter) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var n int64
+	for i := range m.records {
+		if matches(&m.records[i], f) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func matches(r *AttendanceRecord, f RecordFilter) bool {
+	if f.UserID != "" && r.UserID != f.UserID {
+		return false
+	}
+	if !f.From.IsZero() && r.CheckinTime.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !r.CheckinTime.Before(f.To) {
+		return false
+	}
+	switch f.State {
+	case OpenSession:
+		return r.CheckoutTime == nil
+	case ClosedSession:
+		return r.CheckoutTime != nil
+	}
+	return true
+}
+
+// after reports whether r sorts strictly after c in the given direction.
+func after(r *AttendanceRecord, c *RecordCursor, ascending bool) bool {
+	if !r.CheckinTime.Equal(c.CheckinTime) {
+		return r.CheckinTime.After(c.CheckinTime) == ascending
+	}
+	if r.ID == c.ID {
+		return false
+	}
+	return (r.ID.Hex() > c.ID.Hex()) == ascending
+}
+
+func (m *memoryStore) DailyTotals(ctx context.Context, userID string, from, to time.Time, loc *time.Location, now time.Time) ([]DayTotal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	type key struct {
+		user string
+		day  time.Time
+	}
+	byKey := map[key]*DayTotal{}
+	var keys []key
+	for i := range m.records {
+		r := &m.records[i]
+		if userID != "" && r.UserID != userID {
+			continue
+		}
+		end := now
+		if r.CheckoutTime != nil {
+			end = *r.CheckoutTime
+		}
+		for _, seg := range splitByDay(r.CheckinTime, end, loc) {
+			if seg.day.Before(from) || !seg.day.Before(to) {
+				continue
+			}
+			k := key{r.UserID, seg.day}
+			t, ok := byKey[k]
+			if !ok {
+				t = &DayTotal{UserID: r.UserID, Day: seg.day, FirstIn: seg.start, LastOut: seg.end}
+				byKey[k] = t
+				keys = append(keys, k)
+			}
+			if seg.start.Before(t.FirstIn) {
+				t.FirstIn = seg.start
+			}
+			if seg.end.After(t.LastOut) {
+				t.LastOut = seg.end
+			}
+			t.Sessions++
+			t.Worked += seg.end.Sub(seg.start) - r.breakTime(seg.start, seg.end)
+			t.StillIn = t.StillIn || r.CheckoutTime == nil
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].day.Equal(keys[j].day) {
+			return keys[i].day.Before(keys[j].day)
+		}
+		return keys[i].user < keys[j].user
+	})
+	totals := make([]DayTotal, 0, len(keys))
+	for _, k := range keys {
+		totals = append(totals, *byKey[k])
+	}
+	return totals, nil
+}
+
+// daySegment is the part of a session that falls on one calendar day.
+type daySegment struct {
+	day        time.Time // midnight in loc
+	start, end time.Time
+}
+
+// splitByDay cuts [start, end) at each midnight in loc. A zero-length
+// session yields a single empty segment on its checkin day.
+func splitByDay(start, end time.Time, loc *time.Location) []daySegment {
+	var segs []daySegment
+	day := startOfDay(start, loc)
+	for {
+		next := day.AddDate(0, 0, 1)
+		seg := daySegment{day: day, start: start, end: end}
+		if seg.start.Before(day) {
+			seg.start = day
+		}
+		if seg.end.After(next) {
+			seg.end = next
+		}
+		segs = append(segs, seg)
+		if !end.After(next) {
+			return segs
+		}
+		day = next
+	}
+}
+
+// startOfDay returns midnight of t's calendar day in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var t0 = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // a Monday
+
+// insertRecord stores a record for userID checked in at in, closed at out
+// unless out is zero.
+func insertRecord(t *testing.T, m *memoryStore, userID string, in, out time.Time) *AttendanceRecord {
+	t.Helper()
+	rec := &AttendanceRecord{ID: primitive.NewObjectID(), UserID: userID, CheckinTime: in}
+	if !out.IsZero() {
+		rec.CheckoutTime = &out
+	}
+	m.mu.Lock()
+	m.records = append(m.records, *rec) // bypasses the open-session check
+	m.mu.Unlock()
+	return rec
+}
+
+func TestMemoryStoreGetAndLatest(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryStore()
+	old := insertRecord(t, m, "u1", t0, t0.Add(time.Hour))
+	latest := insertRecord(t, m, "u1", t0.Add(24*time.Hour), time.Time{})
+	insertRecord(t, m, "u2", t0.Add(48*time.Hour), time.Time{})
+
+	if r, err := m.Get(ctx, old.ID); err != nil || r.ID != old.ID {
+		t.Errorf("Get = %v, %v; want %s", r, err, old.ID.Hex())
+	}
+	if _, err := m.Get(ctx, primitive.NewObjectID()); err != ErrNotFound {
+		t.Errorf("Get unknown id: err = %v, want ErrNotFound", err)
+	}
+	if r, err := m.LatestByUser(ctx, "u1"); err != nil || r.ID != latest.ID {
+		t.Errorf("LatestByUser = %v, %v; want %s", r, err, latest.ID.Hex())
+	}
+	if _, err := m.LatestByUser(ctx, "nobody"); err != ErrNotFound {
+		t.Errorf("LatestByUser unknown user: err = %v, want ErrNotFound", err)
+	}
+	if r, err := m.OpenByUser(ctx, "u1"); err != nil || r.ID != latest.ID {
+		t.Errorf("OpenByUser = %v, %v; want %s", r, err, latest.ID.Hex())
+	}
+}
+
+func TestMemoryStoreCount(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryStore()
+	insertRecord(t, m, "u1", t0, t0.Add(time.Hour))
+	insertRecord(t, m, "u1", t0.Add(24*time.Hour), time.Time{})
+	insertRecord(t, m, "u2", t0.Add(48*time.Hour), time.Time{})
+
+	for _, tc := range []struct {
+		name string
+		f    RecordFilter
+		want int64
+	}{
+		{"all", RecordFilter{}, 3},
+		{"user", RecordFilter{UserID: "u1"}, 2},
+		{"open", RecordFilter{State: OpenSession}, 2},
+		{"closed", RecordFilter{State: ClosedSession}, 1},
+		{"from", RecordFilter{From: t0.Add(24 * time.Hour)}, 2},
+		{"to is exclusive", RecordFilter{To: t0.Add(24 * time.Hour)}, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if n, err := m.Count(ctx, tc.f); err != nil || n != tc.want {
+				t.Errorf("Count = %d, %v; want %d", n, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreInsertOneOpenSession(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryStore()
+	open := &AttendanceRecord{ID: primitive.NewObjectID(), UserID: "u1", CheckinTime: t0}
+	if err := m.Insert(ctx, open); err != nil {
+		t.Fatal(err)
+	}
+	dup := &AttendanceRecord{ID: primitive.NewObjectID(), UserID: "u1", CheckinTime: t0.Add(time.Minute)}
+	if err := m.Insert(ctx, dup); err != ErrOpenSession {
+		t.Errorf("second open record for u1: err = %v, want ErrOpenSession", err)
+	}
+	other := &AttendanceRecord{ID: primitive.NewObjectID(), UserID: "u2", CheckinTime: t0}
+	if err := m.Insert(ctx, other); err != nil {
+		t.Errorf("open record for another user: %v", err)
+	}
+	if _, err := m.CloseSession(ctx, open.ID, t0.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Insert(ctx, dup); err != nil {
+		t.Errorf("insert after checkout: %v", err)
+	}
+}
+
+func TestMemoryStoreCloseSession(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryStore()
+	rec := insertRecord(t, m, "u1", t0, time.Time{})
+	first := t0.Add(8 * time.Hour)
+
+	r, err := m.CloseSession(ctx, rec.ID, first)
+	if err != nil || r.CheckoutTime == nil || !r.CheckoutTime.Equal(first) {
+		t.Fatalf("CloseSession = %v, %v; want checkout at %v", r, err, first)
+	}
+	// A second close keeps the original checkout time.
+	r, err = m.CloseSession(ctx, rec.ID, first.Add(time.Hour))
+	if err != ErrSessionClosed {
+		t.Errorf("second CloseSession: err = %v, want ErrSessionClosed", err)
+	}
+	if r == nil || !r.CheckoutTime.Equal(first) {
+		t.Errorf("second CloseSession returned %v, want the record closed at %v", r, first)
+	}
+	if _, err := m.CloseSession(ctx, primitive.NewObjectID(), first); err != ErrNotFound {
+		t.Errorf("CloseSession unknown id: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSplitByDay(t *testing.T) {
+	kolkata, err := loadZone("Asia/Kolkata")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	london, err := loadZone("Europe/London")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	at := func(loc *time.Location, d, h, m int) time.Time { return time.Date(2026, 3, d, h, m, 0, 0, loc) }
+
+	for _, tc := range []struct {
+		name       string
+		start, end time.Time
+		loc        *time.Location
+		want       []time.Duration // segment lengths, one per day
+	}{
+		{"same day", at(time.UTC, 2, 9, 0), at(time.UTC, 2, 17, 0), time.UTC, []time.Duration{8 * time.Hour}},
+		{"zero length", at(time.UTC, 2, 9, 0), at(time.UTC, 2, 9, 0), time.UTC, []time.Duration{0}},
+		{"ends at midnight", at(time.UTC, 2, 20, 0), at(time.UTC, 3, 0, 0), time.UTC, []time.Duration{4 * time.Hour}},
+		{"overnight", at(time.UTC, 2, 22, 0), at(time.UTC, 3, 6, 0), time.UTC, []time.Duration{2 * time.Hour, 6 * time.Hour}},
+		{"three days", at(time.UTC, 2, 12, 0), at(time.UTC, 4, 12, 0), time.UTC, []time.Duration{12 * time.Hour, 24 * time.Hour, 12 * time.Hour}},
+		// 20:00 UTC is 01:30 the next day in Kolkata.
+		{"other zone", at(time.UTC, 2, 17, 0), at(time.UTC, 2, 20, 0), kolkata, []time.Duration{90 * time.Minute, 90 * time.Minute}},
+		// Clocks go forward on 29 March 2026; that day has 23 hours.
+		{"dst day", at(london, 29, 0, 0), at(london, 30, 0, 0), london, []time.Duration{23 * time.Hour}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			segs := splitByDay(tc.start, tc.end, tc.loc)
+			if len(segs) != len(tc.want) {
+				t.Fatalf("got %d segments %v, want %d", len(segs), segs, len(tc.want))
+			}
+			for i, seg := range segs {
+				if d := seg.end.Sub(seg.start); d != tc.want[i] {
+					t.Errorf("segment %d lasts %v, want %v", i, d, tc.want[i])
+				}
+				if !seg.day.Equal(startOfDay(seg.start, tc.loc)) {
+					t.Errorf("segment %d day %v is not the start's midnight", i, seg.day)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStoreDailyTotalsAcrossMidnight(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryStore()
+	day1 := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	insertRecord(t, m, "u1", day1.Add(8*time.Hour), day1.Add(12*time.Hour))
+	insertRecord(t, m, "u1", day1.Add(22*time.Hour), day1.Add(30*time.Hour)) // 22:00 to 06:00
+	insertRecord(t, m, "u2", day1.Add(33*time.Hour), time.Time{})            // still in
+	now := day1.Add(35 * time.Hour)
+
+	totals, err := m.DailyTotals(ctx, "", day1, day1.AddDate(0, 0, 2), time.UTC, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []DayTotal{
+		{UserID: "u1", Day: day1, FirstIn: day1.Add(8 * time.Hour), LastOut: day1.Add(24 * time.Hour), Sessions: 2, Worked: 6 * time.Hour},
+		{UserID: "u1", Day: day1.AddDate(0, 0, 1), FirstIn: day1.Add(24 * time.Hour), LastOut: day1.Add(30 * time.Hour), Sessions: 1, Worked: 6 * time.Hour},
+		{UserID: "u2", Day: day1.AddDate(0, 0, 1), FirstIn: day1.Add(33 * time.Hour), LastOut: now, Sessions: 1, Worked: 2 * time.Hour, StillIn: true},
+	}
+	if len(totals) != len(want) {
+		t.Fatalf("got %d totals %+v, want %d", len(totals), totals, len(want))
+	}
+	for i := range want {
+		g, w := totals[i], want[i]
+		if g.UserID != w.UserID || !g.Day.Equal(w.Day) || !g.FirstIn.Equal(w.FirstIn) || !g.LastOut.Equal(w.LastOut) ||
+			g.Sessions != w.Sessions || g.Worked != w.Worked || g.StillIn != w.StillIn {
+			t.Errorf("total %d = %+v, want %+v", i, g, w)
+		}
+	}
+
+	// A range starting on day two still counts the overnight session's tail.
+	totals, _ = m.DailyTotals(ctx, "u1", day1.AddDate(0, 0, 1), day1.AddDate(0, 0, 2), time.UTC, now)
+	if len(totals) != 1 || totals[0].Worked != 6*time.Hour {
+		t.Errorf("day two totals = %+v, want one day with 6h", totals)
+	}
+}
+
+func TestMemoryStoreEachStops(t *testing.T) {
+	m := newMemoryStore()
+	for i := 0; i < 3; i++ {
+		insertRecord(t, m, "u1", t0.Add(time.Duration(i)*time.Hour), t0.Add(time.Duration(i)*time.Hour+time.Minute))
+	}
+	stop := errors.New("stop")
+	seen := 0
+	err := m.Each(context.Background(), RecordQuery{}, func(*AttendanceRecord) error {
+		seen++
+		return stop
+	})
+	if err != stop || seen != 1 {
+		t.Errorf("Each = %v after %d records, want the callback's error after 1", err, seen)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := m.Each(ctx, RecordQuery{}, func(*AttendanceRecord) error { return nil }); err != context.Canceled {
+		t.Errorf("Each with a cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestCloseDuplicateOpenSessions(t *testing.T) {
+	ctx := context.Background()
+	m := newMemoryStore()
+	oldest := insertRecord(t, m, "u1", t0, time.Time{})
+	older := insertRecord(t, m, "u1", t0.Add(time.Hour), time.Time{})
+	newest := insertRecord(t, m, "u1", t0.Add(2*time.Hour), time.Time{})
+	only := insertRecord(t, m, "u2", t0, time.Time{})
+	insertRecord(t, m, "u3", t0, t0.Add(time.Hour))
+
+	n, err := closeDuplicateOpenSessions(ctx, m)
+	if err != nil || n != 2 {
+		t.Fatalf("closeDuplicateOpenSessions = %d, %v; want 2", n, err)
+	}
+	for _, tc := range []struct {
+		name string
+		rec  *AttendanceRecord
+		out  time.Time // zero while open
+	}{
+		{"oldest closes at the next check-in", oldest, t0.Add(time.Hour)},
+		{"older closes at the newest check-in", older, t0.Add(2 * time.Hour)},
+		{"newest stays open", newest, time.Time{}},
+		{"single open session stays open", only, time.Time{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := m.Get(ctx, tc.rec.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.out.IsZero() {
+				if r.CheckoutTime != nil {
+					t.Errorf("closed at %v, want open", r.CheckoutTime)
+				}
+				return
+			}
+			if r.CheckoutTime == nil || !r.CheckoutTime.Equal(tc.out) || !r.AutoClosed || r.CloseReason != closeReasonDuplicate {
+				t.Errorf("record = %+v, want auto-closed at %v as a duplicate", r, tc.out)
+			}
+		})
+	}
+
+	if n, err := closeDuplicateOpenSessions(ctx, m); err != nil || n != 0 {
+		t.Errorf("second run = %d, %v; want nothing to close", n, err)
+	}
+}
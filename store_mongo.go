@@ -0,0 +1,422 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoStore keeps attendance records in a MongoDB collection.
+type mongoStore struct {
+	collection *mongo.Collection
+}
+
+func newMongoStore(ctx context.Context, collection *mongo.Collection) (*mongoStore, error) {
+	m := &mongoStore{collection: collection}
+	if err := m.ensureIndexes(ctx); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ensureIndexes creates the indexes the store relies on. The partial unique
+// index on user_id makes "one open session per user" hold across replicas.
+func (m *mongoStore) ensureIndexes(ctx context.Context) error {
+	// Records opened before the index existed lack the open flag and would
+	// escape it, and a user may have several of them; keep only the newest
+	// open so the unique index can be built.
+	_, err := m.collection.UpdateMany(ctx,
+		bson.M{"checkout_time": bson.M{"$exists": false}, "open": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"open": true}},
+	)
+	if err != nil {
+		return err
+	}
+	if _, err := closeDuplicateOpenSessions(ctx, m); err != nil {
+		return fmt.Errorf("close duplicate open sessions: %w", err)
+	}
+	_, err = m.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().
+				SetName("one_open_session_per_user").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"open": true}),
+		},
+		{
+			// Open sessions only, for the auto-checkout sweep and the
+			// checked-in gauge.
+			Keys: bson.D{{Key: "checkin_time", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().
+				SetName("open_sessions").
+				SetPartialFilterExpression(bson.M{"open": true}),
+		},
+		{
+			Keys: bson.D{{Key: "checkin_time", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "checkin_time", Value: -1}, {Key: "_id", Value: -1}},
+		},
+	})
+	return err
+}
+
+func (m *mongoStore) Insert(ctx context.Context, rec *AttendanceRecord) error {
+	rec.Open = rec.CheckoutTime == nil
+	_, err := m.collection.InsertOne(ctx, rec)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrOpenSession
+	}
+	return err
+}
+
+func (m *mongoStore) Get(ctx context.Context, id primitive.ObjectID) (*AttendanceRecord, error) {
+	var r AttendanceRecord
+	err := m.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&r)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// closeUpdate is the pipeline update that checks a record out at at and
+// sets the extra fields. Like closeBreaks, it ends a running break at at and
+// drops whatever part of a break lies after it.
+func closeUpdate(at time.Time, extra bson.M) mongo.Pipeline {
+	set := bson.M{
+		"checkout_time": at,
+		"breaks": bson.M{"$map": bson.M{
+			"input": bson.M{"$filter": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$breaks", bson.A{}}},
+				"cond":  bson.M{"$lt": bson.A{"$$this.start", at}},
+			}},
+			"in": bson.M{
+				"start": "$$this.start",
+				"end":   bson.M{"$min": bson.A{bson.M{"$ifNull": bson.A{"$$this.end", at}}, at}},
+			},
+		}},
+	}
+	for k, v := range extra {
+		set[k] = v
+	}
+	return mongo.Pipeline{
+		{{Key: "$set", Value: set}},
+		{{Key: "$unset", Value: "open"}},
+	}
+}
+
+func (m *mongoStore) CloseSession(ctx context.Context, id primitive.ObjectID, at time.Time) (*AttendanceRecord, error) {
+	update := closeUpdate(at, nil)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	filter := bson.M{"_id": id, "checkout_time": bson.M{"$exists": false}}
+
+	var updated AttendanceRecord
+	err := m.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		// Either the record does not exist or it is already closed.
+		var existing AttendanceRecord
+		err = m.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&existing)
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &existing, ErrSessionClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (m *mongoStore) AutoCloseSession(ctx context.Context, id primitive.ObjectID, at time.Time, reason string) (*AttendanceRecord, error) {
+	update := closeUpdate(at, bson.M{"auto_closed": true, "close_reason": reason})
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	filter := bson.M{"_id": id, "checkout_time": bson.M{"$exists": false}}
+
+	var updated AttendanceRecord
+	err := m.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		// Checked out (or deleted) since the sweep found it.
+		return nil, ErrSessionClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// StartBreak pushes the break only if the filter proves it valid; when
+// nothing matches, the open record is re-read to report why.
+func (m *mongoStore) StartBreak(ctx context.Context, userID string, at time.Time) (*AttendanceRecord, error) {
+	filter := bson.M{
+		"user_id":       userID,
+		"checkout_time": bson.M{"$exists": false},
+		"checkin_time":  bson.M{"$lte": at},
+		"breaks": bson.M{"$not": bson.M{"$elemMatch": bson.M{"$or": bson.A{
+			bson.M{"end": bson.M{"$exists": false}},
+			bson.M{"end": bson.M{"$gt": at}},
+		}}}},
+	}
+	update := bson.M{"$push": bson.M{"breaks": Break{Start: at}}}
+	return m.updateBreak(ctx, userID, at, filter, update, checkBreakStart)
+}
+
+func (m *mongoStore) EndBreak(ctx context.Context, userID string, at time.Time) (*AttendanceRecord, error) {
+	filter := bson.M{
+		"user_id":       userID,
+		"checkout_time": bson.M{"$exists": false},
+		"breaks": bson.M{"$elemMatch": bson.M{
+			"end":   bson.M{"$exists": false},
+			"start": bson.M{"$lte": at},
+		}},
+	}
+	update := bson.M{"$set": bson.M{"breaks.$.end": at}}
+	return m.updateBreak(ctx, userID, at, filter, update, checkBreakEnd)
+}
+
+func (m *mongoStore) updateBreak(ctx context.Context, userID string, at time.Time, filter, update bson.M, check func(*AttendanceRecord, time.Time) error) (*AttendanceRecord, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updated AttendanceRecord
+	err := m.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		open, err := m.OpenByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := check(open, at); err != nil {
+			return nil, err
+		}
+		// Valid now, so the record changed in between; report a conflict.
+		return nil, ErrInvalidBreak
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (m *mongoStore) LatestByUser(ctx context.Context, userID string) (*AttendanceRecord, error) {
+	filter := bson.M{"user_id": userID}
+	opts := options.FindOne().SetSort(bson.D{{Key: "checkin_time", Value: -1}})
+	var r AttendanceRecord
+	err := m.collection.FindOne(ctx, filter, opts).Decode(&r)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (m *mongoStore) OpenByUser(ctx context.Context, userID string) (*AttendanceRecord, error) {
+	filter := bson.M{"user_id": userID, "checkout_time": bson.M{"$exists": false}}
+	var r AttendanceRecord
+	err := m.collection.FindOne(ctx, filter).Decode(&r)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// filterDoc translates a RecordFilter into a Mongo filter document.
+func filterDoc(f RecordFilter) bson.M {
+	filter := bson.M{}
+	if f.UserID != "" {
+		filter["user_id"] = f.UserID
+	}
+	checkin := bson.M{}
+	if !f.From.IsZero() {
+		checkin["$gte"] = f.From
+	}
+	if !f.To.IsZero() {
+		checkin["$lt"] = f.To
+	}
+	if len(checkin) > 0 {
+		filter["checkin_time"] = checkin
+	}
+	switch f.State {
+	case OpenSession:
+		// Served by the open_sessions partial index; a missing field
+		// cannot be, and would scan every record.
+		filter["open"] = true
+	case ClosedSession:
+		filter["checkout_time"] = bson.M{"$exists": true}
+	}
+	return filter
+}
+
+func (m *mongoStore) List(ctx context.Context, q RecordQuery) ([]AttendanceRecord, error) {
+	var records []AttendanceRecord
+	err := m.Each(ctx, q, func(r *AttendanceRecord) error {
+		records = append(records, *r)
+		return nil
+	})
+	return records, err
+}
+
+func (m *mongoStore) Each(ctx context.Context, q RecordQuery, fn func(*AttendanceRecord) error) error {
+	filter := filterDoc(q.RecordFilter)
+	dir, cmp := -1, "$lt"
+	if q.Ascending {
+		dir, cmp = 1, "$gt"
+	}
+	if q.After != nil {
+		after := bson.M{"$or": bson.A{
+			bson.M{"checkin_time": bson.M{cmp: q.After.CheckinTime}},
+			bson.M{"checkin_time": q.After.CheckinTime, "_id": bson.M{cmp: q.After.ID}},
+		}}
+		filter = bson.M{"$and": bson.A{filter, after}}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "checkin_time", Value: dir}, {Key: "_id", Value: dir}})
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+	cursor, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for n := 0; cursor.Next(ctx); n++ {
+		var r AttendanceRecord
+		if err := cursor.Decode(&r); err != nil {
+			return fmt.Errorf("decode record %d (%v): %w", n, cursor.Current.Lookup("_id"), err)
+		}
+		if err := fn(&r); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (m *mongoStore) Count(ctx context.Context, f RecordFilter) (int64, error) {
+	return m.collection.CountDocuments(ctx, filterDoc(f))
+}
+
+// DailyTotals needs MongoDB 5.0 for $dateTrunc, $dateAdd and $dateDiff.
+func (m *mongoStore) DailyTotals(ctx context.Context, userID string, from, to time.Time, loc *time.Location, now time.Time) ([]DayTotal, error) {
+	tz := loc.String()
+	match := bson.M{
+		"checkin_time": bson.M{"$lt": to},
+		"$or": bson.A{
+			bson.M{"checkout_time": bson.M{"$exists": false}},
+			bson.M{"checkout_time": bson.M{"$gte": from}},
+		},
+	}
+	if userID != "" {
+		match["user_id"] = userID
+	}
+	dayAfter := func(start, days interface{}) bson.M {
+		return bson.M{"$dateAdd": bson.M{"startDate": start, "unit": "day", "amount": days, "timezone": tz}}
+	}
+	pipeline := mongo.Pipeline{
+		// Sessions overlapping [from, to).
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{
+			"end":      bson.M{"$ifNull": bson.A{"$checkout_time", now}},
+			"firstDay": bson.M{"$dateTrunc": bson.M{"date": "$checkin_time", "unit": "day", "timezone": tz}},
+		}}},
+		// One document per calendar day the session touches.
+		{{Key: "$addFields", Value: bson.M{
+			"dayOffset": bson.M{"$range": bson.A{0, bson.M{"$add": bson.A{
+				bson.M{"$dateDiff": bson.M{"startDate": "$checkin_time", "endDate": "$end", "unit": "day", "timezone": tz}},
+				1,
+			}}}},
+		}}},
+		{{Key: "$unwind", Value: "$dayOffset"}},
+		{{Key: "$addFields", Value: bson.M{
+			"dayStart": dayAfter("$firstDay", "$dayOffset"),
+			"dayEnd":   dayAfter("$firstDay", bson.M{"$add": bson.A{"$dayOffset", 1}}),
+		}}},
+		// Keep days inside the range and drop the empty tail segment of a
+		// session that ends exactly at midnight.
+		{{Key: "$match", Value: bson.M{"$expr": bson.M{"$and": bson.A{
+			bson.M{"$gte": bson.A{"$dayStart", from}},
+			bson.M{"$lt": bson.A{"$dayStart", to}},
+			bson.M{"$or": bson.A{
+				bson.M{"$eq": bson.A{"$dayOffset", 0}},
+				bson.M{"$lt": bson.A{"$dayStart", "$end"}},
+			}},
+		}}}}},
+		{{Key: "$addFields", Value: bson.M{
+			"segStart": bson.M{"$max": bson.A{"$checkin_time", "$dayStart"}},
+			"segEnd":   bson.M{"$min": bson.A{"$end", "$dayEnd"}},
+		}}},
+		// Break time inside the segment; a running break lasts until now.
+		{{Key: "$project", Value: bson.M{
+			"user_id":  1,
+			"dayStart": 1,
+			"segStart": 1,
+			"segEnd":   1,
+			"breakMs": bson.M{"$reduce": bson.M{
+				"input":        bson.M{"$ifNull": bson.A{"$breaks", bson.A{}}},
+				"initialValue": 0,
+				"in": bson.M{"$add": bson.A{"$$value", bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{
+					bson.M{"$min": bson.A{bson.M{"$ifNull": bson.A{"$$this.end", "$segEnd"}}, "$segEnd"}},
+					bson.M{"$max": bson.A{"$$this.start", "$segStart"}},
+				}}}}}},
+			}},
+			"open": bson.M{"$eq": bson.A{bson.M{"$type": "$checkout_time"}, "missing"}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      bson.M{"user": "$user_id", "day": "$dayStart"},
+			"firstIn":  bson.M{"$min": "$segStart"},
+			"lastOut":  bson.M{"$max": "$segEnd"},
+			"sessions": bson.M{"$sum": 1},
+			"workedMs": bson.M{"$sum": bson.M{"$subtract": bson.A{bson.M{"$subtract": bson.A{"$segEnd", "$segStart"}}, "$breakMs"}}},
+			"open":     bson.M{"$max": "$open"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.day", Value: 1}, {Key: "_id.user", Value: 1}}}},
+	}
+
+	cursor, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID struct {
+			User string    `bson:"user"`
+			Day  time.Time `bson:"day"`
+		} `bson:"_id"`
+		FirstIn  time.Time `bson:"firstIn"`
+		LastOut  time.Time `bson:"lastOut"`
+		Sessions int       `bson:"sessions"`
+		WorkedMs int64     `bson:"workedMs"`
+		Open     bool      `bson:"open"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	totals := make([]DayTotal, 0, len(rows))
+	for _, r := range rows {
+		totals = append(totals, DayTotal{
+			UserID:   r.ID.User,
+			Day:      r.ID.Day.In(loc),
+			FirstIn:  r.FirstIn,
+			LastOut:  r.LastOut,
+			Sessions: r.Sessions,
+			Worked:   time.Duration(r.WorkedMs) * time.Millisecond,
+			StillIn:  r.Open,
+		})
+	}
+	return totals, nil
+}
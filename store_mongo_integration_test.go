@@ -0,0 +1,110 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newIntegrationMongoStore returns a store on a throwaway database of the
+// server at MONGO_URI, dropped when the test ends.
+func newIntegrationMongoStore(t *testing.T) *mongoStore {
+	t.Helper()
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		t.Skip("MONGO_URI not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := client.Database("attendance_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+	m, err := newMongoStore(ctx, db.Collection("records"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMongoDailyTotalsMatchMemory(t *testing.T) {
+	london, err := loadZone("Europe/London")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	ctx := context.Background()
+	db := newIntegrationMongoStore(t)
+	memory := newMemoryStore()
+
+	utc := func(d, h, m int) time.Time { return time.Date(2026, 3, d, h, m, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	records := []AttendanceRecord{
+		// Across midnight.
+		{UserID: "u1", CheckinTime: utc(2, 22, 0), CheckoutTime: ptr(utc(3, 6, 0))},
+		// With a break.
+		{UserID: "u1", CheckinTime: utc(3, 8, 0), CheckoutTime: ptr(utc(3, 12, 0)),
+			Breaks: []Break{{Start: utc(3, 10, 0), End: ptr(utc(3, 10, 30))}}},
+		// Still open, across midnight up to now.
+		{UserID: "u2", CheckinTime: utc(3, 20, 0)},
+		// London clocks go forward at 01:00 UTC on 29 March.
+		{UserID: "u3", CheckinTime: utc(28, 23, 0), CheckoutTime: ptr(utc(29, 3, 0))},
+		{UserID: "u3", CheckinTime: utc(29, 22, 30), CheckoutTime: ptr(utc(30, 1, 0))},
+	}
+	for i := range records {
+		r := records[i]
+		r.ID = primitive.NewObjectID()
+		for _, s := range []AttendanceStore{db, memory} {
+			rec := r
+			if err := s.Insert(ctx, &rec); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	now := utc(4, 1, 0)
+
+	for _, tc := range []struct {
+		name     string
+		userID   string
+		from, to time.Time
+		loc      *time.Location
+	}{
+		{"all users, UTC", "", utc(2, 0, 0), utc(5, 0, 0), time.UTC},
+		{"one user from the second day", "u1", utc(3, 0, 0), utc(4, 0, 0), time.UTC},
+		{"DST week in London", "", time.Date(2026, 3, 28, 0, 0, 0, 0, london), time.Date(2026, 3, 31, 0, 0, 0, 0, london), london},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := memory.DailyTotals(ctx, tc.userID, tc.from, tc.to, tc.loc, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := db.DailyTotals(ctx, tc.userID, tc.from, tc.to, tc.loc, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("mongo gave %d totals %+v, memory %d %+v", len(got), got, len(want), want)
+			}
+			for i := range want {
+				g, w := got[i], want[i]
+				if g.UserID != w.UserID || !g.Day.Equal(w.Day) || !g.FirstIn.Equal(w.FirstIn) || !g.LastOut.Equal(w.LastOut) ||
+					g.Sessions != w.Sessions || g.Worked != w.Worked || g.StillIn != w.StillIn {
+					t.Errorf("total %d: mongo %+v, memory %+v", i, g, w)
+				}
+			}
+		})
+	}
+}
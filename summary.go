@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxSummaryDays bounds the date range of a single summary request.
+const maxSummaryDays = 366
+
+func (s *attendanceServer) GetUserSummary(ctx context.Context, req *pb.GetUserSummaryRequest) (*pb.GetUserSummaryResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	if err := s.policy.canRead(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	loc, err := s.locationFor(ctx, req.GetTimeZone(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from, to, err := summaryRange(req.GetStartDate(), req.GetEndDate(), now, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.store.DailyTotals(ctx, req.GetUserId(), from, to, loc, now.UTC())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "aggregate error: %v", err)
+	}
+	byDate := make(map[string]DayTotal, len(totals))
+	for _, t := range totals {
+		byDate[t.Day.In(loc).Format(dateLayout)] = t
+	}
+
+	resp := &pb.GetUserSummaryResponse{UserId: req.GetUserId()}
+	var total time.Duration
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		ds := &pb.DaySummary{Date: date, Worked: durationpb.New(0), WorkedDisplay: "0s"}
+		if t, ok := byDate[date]; ok {
+			worked := t.Worked.Truncate(time.Second)
+			ds.FirstIn = timestamppb.New(t.FirstIn)
+			if !t.StillIn {
+				ds.LastOut = timestamppb.New(t.LastOut)
+			}
+			ds.Sessions = int32(t.Sessions)
+			ds.Worked = durationpb.New(worked)
+			ds.WorkedDisplay = worked.String()
+			ds.StillCheckedIn = t.StillIn
+			total += worked
+		}
+		resp.Days = append(resp.Days, ds)
+	}
+	resp.TotalWorked = durationpb.New(total)
+	resp.TotalWorkedDisplay = total.String()
+	return resp, nil
+}
+
+// summaryRange resolves the inclusive YYYY-MM-DD dates of a summary request
+// into [from, to) in loc. An empty start means Monday of the current week and
+// an empty end means today.
+func summaryRange(startDate, endDate string, now time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	today := startOfDay(now, loc)
+
+	from := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	if startDate != "" {
+		t, err := time.ParseInLocation(dateLayout, startDate, loc)
+		if err != nil {
+			return from, from, status.Error(codes.InvalidArgument, "start_date must be YYYY-MM-DD")
+		}
+		from = t
+	}
+
+	to := today.AddDate(0, 0, 1)
+	if endDate != "" {
+		t, err := time.ParseInLocation(dateLayout, endDate, loc)
+		if err != nil {
+			return from, from, status.Error(codes.InvalidArgument, "end_date must be YYYY-MM-DD")
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+
+	if !from.Before(to) {
+		return from, to, status.Error(codes.InvalidArgument, "start_date must not be after end_date")
+	}
+	if to.After(from.AddDate(0, 0, maxSummaryDays)) {
+		return from, to, status.Errorf(codes.InvalidArgument, "date range must not exceed %d days", maxSummaryDays)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// zoneCache memoizes time.LoadLocation, which reads tzdata from disk.
+var zoneCache sync.Map // string -> *time.Location
+
+// loadZone loads an IANA time zone such as "Asia/Kolkata". "Local" is
+// refused: its name is not one MongoDB's date operators understand, and it
+// would make results depend on the host.
+func loadZone(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, errors.New(`"Local" is not an IANA time zone`)
+	}
+	if loc, ok := zoneCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	zoneCache.Store(name, loc)
+	return loc, nil
+}
+
+// location resolves the time zone a request asked for, falling back to the
+// server default when the request leaves it empty.
+func (s *attendanceServer) location(name string) (*time.Location, error) {
+	if name == "" {
+		return s.loc, nil
+	}
+	loc, err := loadZone(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown time_zone %q", name)
+	}
+	return loc, nil
+}
+
+// locationFor resolves the time zone for a request about userID: the
+// request's own time_zone, else the user's stored zone, else the server
+// default. Every per-user RPC uses it so a record renders the same way on
+// check-in and check-out.
+func (s *attendanceServer) locationFor(ctx context.Context, name, userID string) (*time.Location, error) {
+	if name == "" && userID != "" {
+		if u, err := s.users.Get(ctx, userID); err == nil {
+			name = u.TimeZone
+		}
+	}
+	return s.location(name)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "attendance1/proto"
+)
+
+// TestUserTimeZoneUsedEverywhere checks that a user's stored zone renders
+// every response about them, not just check-in.
+func TestUserTimeZoneUsedEverywhere(t *testing.T) {
+	tokyo, err := loadZone("Asia/Tokyo")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	ctx := context.Background()
+	s := newTestServer(t, "u1")
+	tz := "Asia/Tokyo"
+	if _, err := s.users.Update(ctx, "u1", UserUpdate{TimeZone: &tz}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	jst := time.Now().In(tokyo).Format("MST")
+	inZone := func(t *testing.T, rpc string, r *pb.AttendanceRecordResponse) {
+		t.Helper()
+		if !strings.HasSuffix(r.GetCheckinTime(), " "+jst) {
+			t.Errorf("%s: checkin_time %q not rendered in %s", rpc, r.GetCheckinTime(), tz)
+		}
+		if r.GetCheckoutTime() != "" && !strings.HasSuffix(r.GetCheckoutTime(), " "+jst) {
+			t.Errorf("%s: checkout_time %q not rendered in %s", rpc, r.GetCheckoutTime(), tz)
+		}
+	}
+
+	in, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inZone(t, "CheckIn", in)
+	r, err := s.StartBreak(ctx, &pb.StartBreakRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inZone(t, "StartBreak", r)
+	if r, err = s.EndBreak(ctx, &pb.EndBreakRequest{UserId: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	inZone(t, "EndBreak", r)
+	if r, err = s.CheckOut(ctx, &pb.CheckOutRequest{RecordId: in.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	inZone(t, "CheckOut", r)
+	if r, err = s.GetAttendance(ctx, &pb.GetAttendanceRequest{UserId: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	inZone(t, "GetAttendance", r)
+	list, err := s.ListAttendance(ctx, &pb.ListAttendanceRequest{UserId: "u1"})
+	if err != nil || len(list.GetRecords()) != 1 {
+		t.Fatalf("ListAttendance = %v, %v", list, err)
+	}
+	inZone(t, "ListAttendance", list.GetRecords()[0])
+
+	if _, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	if r, err = s.CheckOutUser(ctx, &pb.CheckOutUserRequest{UserId: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	inZone(t, "CheckOutUser", r)
+
+	// An explicit time_zone still wins.
+	r, err = s.GetAttendance(ctx, &pb.GetAttendanceRequest{UserId: "u1", TimeZone: "UTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(r.GetCheckinTime(), " UTC") {
+		t.Errorf("checkin_time %q ignores the requested UTC", r.GetCheckinTime())
+	}
+}
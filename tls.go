@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloader serves a certificate, key and optional CA bundle from disk and
+// reloads them when the files change, so rotated certificates are picked up
+// without a restart.
+type certReloader struct {
+	certFile, keyFile, caFile string
+
+	mu    sync.RWMutex
+	cert  *tls.Certificate
+	pool  *x509.CertPool // nil without a CA file
+	stamp string         // modification times of the loaded files
+}
+
+func newCertReloader(certFile, keyFile, caFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// fileStamp summarizes the modification times of the watched files.
+func (r *certReloader) fileStamp() (string, error) {
+	stamp := ""
+	for _, f := range []string{r.certFile, r.keyFile, r.caFile} {
+		if f == "" {
+			continue
+		}
+		fi, err := os.Stat(f)
+		if err != nil {
+			return "", err
+		}
+		stamp += fi.ModTime().String() + "|"
+	}
+	return stamp, nil
+}
+
+func (r *certReloader) reload() error {
+	stamp, err := r.fileStamp()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS key pair: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("read TLS CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("TLS CA file has no certificates")
+		}
+	}
+
+	r.mu.Lock()
+	r.cert, r.pool, r.stamp = &cert, pool, stamp
+	r.mu.Unlock()
+	return nil
+}
+
+// watch polls the files every interval and reloads them when they change,
+// until ctx is done. A failed reload keeps serving the previous certificates.
+func (r *certReloader) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		stamp, err := r.fileStamp()
+		r.mu.RLock()
+		changed := err == nil && stamp != r.stamp
+		r.mu.RUnlock()
+		if !changed {
+			continue
+		}
+		if err := r.reload(); err != nil {
+			slog.Error("TLS reload failed, keeping previous certificates", "error", err)
+			continue
+		}
+		slog.Info("TLS certificates reloaded")
+	}
+}
+
+func (r *certReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, r.pool
+}
+
+// serverConfig returns a server-side config that always uses the latest
+// certificate and CA. When a CA is loaded, client certificates are verified
+// against it according to clientAuth; tls.NoClientCert never asks for one.
+func (r *certReloader) serverConfig(clientAuth tls.ClientAuthType, nextProtos []string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool := r.current()
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				NextProtos:   nextProtos,
+			}
+			if pool != nil {
+				cfg.ClientCAs = pool
+				cfg.ClientAuth = clientAuth
+			}
+			return cfg, nil
+		},
+	}
+}
+
+// clientConfig returns a client-side config that presents the latest
+// certificate and verifies the server against the latest CA (or the system
+// roots without one).
+func (r *certReloader) clientConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+		// The built-in verification pins RootCAs at creation time; verify in
+		// VerifyConnection instead so a reloaded CA takes effect.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("server presented no certificate")
+			}
+			_, pool := r.current()
+			opts := x509.VerifyOptions{
+				DNSName:       serverName,
+				Roots:         pool,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, c := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(c)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}
+}
+
+// parseClientAuth maps TLS_CLIENT_AUTH values to tls.ClientAuthType.
+func parseClientAuth(v string) (tls.ClientAuthType, error) {
+	switch v {
+	case "", "require":
+		return tls.RequireAndVerifyClientCert, nil
+	case "request":
+		return tls.VerifyClientCertIfGiven, nil
+	case "none":
+		return tls.NoClientCert, nil
+	}
+	return tls.NoClientCert, fmt.Errorf("unknown TLS_CLIENT_AUTH %q (want require, request or none)", v)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrUserExists is returned by UserStore.Create for a duplicate user_id.
+var ErrUserExists = errors.New("user already exists")
+
+// Mongo Model
+type User struct {
+	UserID    string    `bson:"_id"`
+	Username  string    `bson:"username"`
+	Active    bool      `bson:"active"`
+	TimeZone  string    `bson:"time_zone,omitempty"`
+	ManagerID string    `bson:"manager_id,omitempty"`
+	ShiftID   string    `bson:"shift_id,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// UserUpdate lists the fields to change on a user; nil means unchanged.
+type UserUpdate struct {
+	Username  *string
+	TimeZone  *string
+	ManagerID *string
+	ShiftID   *string
+	Active    *bool
+}
+
+// UserQuery is a paged UserStore.List request, ordered by user id.
+type UserQuery struct {
+	IncludeInactive bool
+	ManagerID       string // only users reporting to this manager, if set
+	ShiftID         string // only users assigned to this shift, if set
+	AfterID         string // resume strictly after this user id
+	Limit           int    // 0 means no limit
+}
+
+// UserStore is the persistence layer behind the user registry.
+type UserStore interface {
+	// Create stores a new user, failing with ErrUserExists on a duplicate id.
+	Create(ctx context.Context, u *User) error
+	// Get returns the user or ErrNotFound.
+	Get(ctx context.Context, userID string) (*User, error)
+	// Update applies the changes, stamps UpdatedAt and returns the user, or
+	// ErrNotFound.
+	Update(ctx context.Context, userID string, upd UserUpdate, at time.Time) (*User, error)
+	// List returns users matching the query.
+	List(ctx context.Context, q UserQuery) ([]User, error)
+}
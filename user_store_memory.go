@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryUserStore keeps the user registry in process memory.
+type memoryUserStore struct {
+	mu    sync.RWMutex
+	users map[string]User
+}
+
+func newMemoryUserStore() *memoryUserStore {
+	return &memoryUserStore{users: map[string]User{}}
+}
+
+func (m *memoryUserStore) Create(ctx context.Context, u *User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.users[u.UserID]; ok {
+		return ErrUserExists
+	}
+	m.users[u.UserID] = *u
+	return nil
+}
+
+func (m *memoryUserStore) Get(ctx context.Context, userID string) (*User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	u, ok := m.users[userID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &u, nil
+}
+
+func (m *memoryUserStore) Update(ctx context.Context, userID string, upd UserUpdate, at time.Time) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.users[userID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if upd.Username != nil {
+		u.Username = *upd.Username
+	}
+	if upd.TimeZone != nil {
+		u.TimeZone = *upd.TimeZone
+	}
+	if upd.ManagerID != nil {
+		u.ManagerID = *upd.ManagerID
+	}
+	if upd.ShiftID != nil {
+		u.ShiftID = *upd.ShiftID
+	}
+	if upd.Active != nil {
+		u.Active = *upd.Active
+	}
+	u.UpdatedAt = at
+	m.users[userID] = u
+	return &u, nil
+}
+
+func (m *memoryUserStore) List(ctx context.Context, q UserQuery) ([]User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var users []User
+	for _, u := range m.users {
+		if !q.IncludeInactive && !u.Active {
+			continue
+		}
+		if q.ManagerID != "" && u.ManagerID != q.ManagerID {
+			continue
+		}
+		if q.ShiftID != "" && u.ShiftID != q.ShiftID {
+			continue
+		}
+		if q.AfterID != "" && u.UserID <= q.AfterID {
+			continue
+		}
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+	if q.Limit > 0 && len(users) > q.Limit {
+		users = users[:q.Limit]
+	}
+	return users, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoUserStore keeps the user registry in a MongoDB collection keyed by
+// user_id.
+type mongoUserStore struct {
+	collection *mongo.Collection
+}
+
+func newMongoUserStore(collection *mongo.Collection) *mongoUserStore {
+	return &mongoUserStore{collection: collection}
+}
+
+func (m *mongoUserStore) Create(ctx context.Context, u *User) error {
+	_, err := m.collection.InsertOne(ctx, u)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrUserExists
+	}
+	return err
+}
+
+func (m *mongoUserStore) Get(ctx context.Context, userID string) (*User, error) {
+	var u User
+	err := m.collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&u)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (m *mongoUserStore) Update(ctx context.Context, userID string, upd UserUpdate, at time.Time) (*User, error) {
+	set := bson.M{"updated_at": at}
+	if upd.Username != nil {
+		set["username"] = *upd.Username
+	}
+	if upd.TimeZone != nil {
+		set["time_zone"] = *upd.TimeZone
+	}
+	if upd.ManagerID != nil {
+		set["manager_id"] = *upd.ManagerID
+	}
+	if upd.ShiftID != nil {
+		set["shift_id"] = *upd.ShiftID
+	}
+	if upd.Active != nil {
+		set["active"] = *upd.Active
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var u User
+	err := m.collection.FindOneAndUpdate(ctx, bson.M{"_id": userID}, bson.M{"$set": set}, opts).Decode(&u)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (m *mongoUserStore) List(ctx context.Context, q UserQuery) ([]User, error) {
+	filter := bson.M{}
+	if !q.IncludeInactive {
+		filter["active"] = true
+	}
+	if q.ManagerID != "" {
+		filter["manager_id"] = q.ManagerID
+	}
+	if q.ShiftID != "" {
+		filter["shift_id"] = q.ShiftID
+	}
+	if q.AfterID != "" {
+		filter["_id"] = bson.M{"$gt": q.AfterID}
+	}
+	opts := options.Find().SetSort(bson.M{"_id": 1})
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+	cursor, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// seedFromRecords registers every user_id found in the attendance records
+// collection that the registry does not know yet, named after their latest
+// record, so people who checked in before the registry existed can still
+// check in. Existing users are left untouched. It returns how many users
+// were added.
+func (m *mongoUserStore) seedFromRecords(ctx context.Context, records *mongo.Collection, now time.Time) (int64, error) {
+	before, err := m.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": bson.M{"$nin": bson.A{nil, ""}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "checkin_time", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$user_id",
+			"username":   bson.M{"$last": "$username"},
+			"created_at": bson.M{"$min": "$checkin_time"},
+		}}},
+		{{Key: "$set", Value: bson.M{"active": true, "updated_at": now}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           m.collection.Name(),
+			"on":             "_id",
+			"whenMatched":    "keepExisting",
+			"whenNotMatched": "insert",
+		}}},
+	}
+	cursor, err := records.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	cursor.Close(ctx)
+	after, err := m.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	return after - before, nil
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// gRPC server struct for the user registry
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	users  UserStore
+	policy *accessPolicy
+}
+
+func toUserResponse(u *User) *pb.User {
+	return &pb.User{
+		UserId:    u.UserID,
+		Username:  u.Username,
+		Active:    u.Active,
+		TimeZone:  u.TimeZone,
+		ManagerId: u.ManagerID,
+		ShiftId:   u.ShiftID,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+	}
+}
+
+// validManager checks that managerID, if set, names another registered user,
+// so a typo cannot hide userID from their real manager.
+func (s *userServer) validManager(ctx context.Context, managerID, userID string) error {
+	if managerID == "" {
+		return nil
+	}
+	if managerID == userID {
+		return status.Error(codes.InvalidArgument, "a user cannot be their own manager")
+	}
+	if _, err := s.users.Get(ctx, managerID); err != nil {
+		if err == ErrNotFound {
+			return status.Errorf(codes.InvalidArgument, "manager_id %q is not a registered user", managerID)
+		}
+		return status.Errorf(codes.Internal, "db error: %v", err)
+	}
+	return nil
+}
+
+func validTimeZone(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := loadZone(name); err != nil {
+		return status.Errorf(codes.InvalidArgument, "unknown time_zone %q", name)
+	}
+	return nil
+}
+
+// --- gRPC Methods ---
+func (s *userServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	if err := s.policy.requireAdmin(ctx, "CreateUser"); err != nil {
+		return nil, err
+	}
+	if req.GetUserId() == "" || req.GetUsername() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and username required")
+	}
+	if err := validTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+	if err := s.validManager(ctx, req.GetManagerId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	u := User{
+		UserID:    req.GetUserId(),
+		Username:  req.GetUsername(),
+		Active:    true,
+		TimeZone:  req.GetTimeZone(),
+		ManagerID: req.GetManagerId(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.users.Create(ctx, &u); err != nil {
+		if err == ErrUserExists {
+			return nil, status.Error(codes.AlreadyExists, "user already exists")
+		}
+		return nil, status.Errorf(codes.Internal, "insert error: %v", err)
+	}
+	return toUserResponse(&u), nil
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	if err := s.policy.canRead(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	u, err := s.users.Get(ctx, req.GetUserId())
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "db error: %v", err)
+	}
+	return toUserResponse(u), nil
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	if err := s.policy.requireAdmin(ctx, "UpdateUser"); err != nil {
+		return nil, err
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	var upd UserUpdate
+	if v := req.GetUsername(); v != "" {
+		upd.Username = &v
+	}
+	if v := req.GetTimeZone(); v != "" {
+		if err := validTimeZone(v); err != nil {
+			return nil, err
+		}
+		upd.TimeZone = &v
+	}
+	if v := req.GetManagerId(); v != "" {
+		if err := s.validManager(ctx, v, req.GetUserId()); err != nil {
+			return nil, err
+		}
+		upd.ManagerID = &v
+	}
+	if req.Active != nil {
+		v := req.GetActive()
+		upd.Active = &v
+	}
+	return s.update(ctx, req.GetUserId(), upd)
+}
+
+func (s *userServer) DeactivateUser(ctx context.Context, req *pb.DeactivateUserRequest) (*pb.User, error) {
+	if err := s.policy.requireAdmin(ctx, "DeactivateUser"); err != nil {
+		return nil, err
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	inactive := false
+	return s.update(ctx, req.GetUserId(), UserUpdate{Active: &inactive})
+}
+
+func (s *userServer) update(ctx context.Context, userID string, upd UserUpdate) (*pb.User, error) {
+	u, err := s.users.Update(ctx, userID, upd, time.Now().UTC())
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	}
+	return toUserResponse(u), nil
+}
+
+func (s *userServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	managerID, err := s.policy.managerScope(ctx, "ListUsers")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	q := UserQuery{IncludeInactive: req.GetIncludeInactive(), ManagerID: managerID, Limit: limit + 1}
+	if tok := req.GetPageToken(); tok != "" {
+		id, err := base64.RawURLEncoding.DecodeString(tok)
+		if err != nil || len(id) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		q.AfterID = string(id)
+	}
+
+	users, err := s.users.List(ctx, q)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "find error: %v", err)
+	}
+
+	resp := &pb.ListUsersResponse{}
+	if len(users) > limit {
+		users = users[:limit]
+		resp.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(users[limit-1].UserID))
+	}
+	for i := range users {
+		resp.Users = append(resp.Users, toUserResponse(&users[i]))
+	}
+	return resp, nil
+}
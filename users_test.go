@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "attendance1/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestUserManagerMustExist(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "boss", "u1")
+	us := &userServer{users: s.users, policy: s.policy}
+
+	_, err := us.CreateUser(ctx, &pb.CreateUserRequest{UserId: "u2", Username: "Two", ManagerId: "bos"})
+	wantCode(t, err, codes.InvalidArgument)
+	if _, err := us.CreateUser(ctx, &pb.CreateUserRequest{UserId: "u2", Username: "Two", ManagerId: "boss"}); err != nil {
+		t.Fatalf("CreateUser with a registered manager: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		manager string
+		code    codes.Code
+	}{
+		{"typo", "bos", codes.InvalidArgument},
+		{"self", "u1", codes.InvalidArgument},
+		{"registered", "boss", codes.OK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := us.UpdateUser(ctx, &pb.UpdateUserRequest{UserId: "u1", ManagerId: tc.manager})
+			wantCode(t, err, tc.code)
+			if err == nil && u.GetManagerId() != tc.manager {
+				t.Errorf("manager_id = %q, want %q", u.GetManagerId(), tc.manager)
+			}
+		})
+	}
+}
+
+func TestUpdateUserActive(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t, "u1")
+	us := &userServer{users: s.users, policy: s.policy}
+	active := func(v bool) *bool { return &v }
+
+	if _, err := us.DeactivateUser(ctx, &pb.DeactivateUserRequest{UserId: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	// Leaving active unset keeps the user deactivated.
+	u, err := us.UpdateUser(ctx, &pb.UpdateUserRequest{UserId: "u1", Username: "renamed"})
+	if err != nil || u.GetActive() {
+		t.Fatalf("UpdateUser = %v, %v; want still deactivated", u, err)
+	}
+	_, err = s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"})
+	wantCode(t, err, codes.FailedPrecondition)
+
+	u, err = us.UpdateUser(ctx, &pb.UpdateUserRequest{UserId: "u1", Active: active(true)})
+	if err != nil || !u.GetActive() {
+		t.Fatalf("reactivate = %v, %v", u, err)
+	}
+	if _, err := s.CheckIn(ctx, &pb.CheckInRequest{UserId: "u1"}); err != nil {
+		t.Errorf("CheckIn after reactivation: %v", err)
+	}
+
+	u, err = us.UpdateUser(ctx, &pb.UpdateUserRequest{UserId: "u1", Active: active(false)})
+	if err != nil || u.GetActive() {
+		t.Errorf("deactivate through UpdateUser = %v, %v", u, err)
+	}
+}